@@ -0,0 +1,174 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package changes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontrol/compliance-masonry/pkg/cli/clierrors"
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/tools/constants"
+	"github.com/opencontrol/compliance-masonry/tools/gitrev"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdChanges reports the changes of the documentation between two git revisions.
+func NewCmdChanges(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "changes",
+		Short: "Report the changes of the documentation since a git revision",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunChanges(out, cmd, args)
+			clierrors.CheckError(err)
+		},
+	}
+	cmd.Flags().StringP("opencontrol", "o", constants.DefaultOpenControlsFolder, "Set opencontrol directory")
+	cmd.Flags().String("since", "", "Git revision of the previous documentation, e.g. a tag")
+	cmd.Flags().String("until", "HEAD", "Git revision of the current documentation")
+	cmd.Flags().StringP("format", "f", "markdown", "Output format: markdown or json")
+	return cmd
+}
+
+// RunChanges runs changes when specified in cli
+func RunChanges(out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("too many arguments. expected none")
+	}
+	config := Config{
+		OpencontrolDir: cmd.Flag("opencontrol").Value.String(),
+		Since:          cmd.Flag("since").Value.String(),
+		Until:          cmd.Flag("until").Value.String(),
+	}
+	if config.Since == "" {
+		return fmt.Errorf("git revision not specified. use --since")
+	}
+	format := cmd.Flag("format").Value.String()
+	if format != "markdown" && format != "json" {
+		return fmt.Errorf("unsupported format '%s'. expected markdown or json", format)
+	}
+	changes, errs := ComputeChanges(config)
+	if errs != nil && len(errs) > 0 {
+		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), 1)
+	}
+	if format == "json" {
+		return changes.WriteJSON(out)
+	}
+	changes.WriteMarkdown(out)
+	return nil
+}
+
+// Config contains the settings for how to compute the changes
+type Config struct {
+	OpencontrolDir string
+	// Since is the git revision of the previous documentation.
+	Since string
+	// Until is the git revision of the current documentation.
+	Until string
+}
+
+// ComputeChanges checks out the opencontrol directory at both revisions and compares their components.
+func ComputeChanges(config Config) (Changes, []error) {
+	previous, errs := loadRevision(config.OpencontrolDir, config.Since)
+	if len(errs) > 0 {
+		return Changes{}, errs
+	}
+	current, errs := loadRevision(config.OpencontrolDir, config.Until)
+	if len(errs) > 0 {
+		return Changes{}, errs
+	}
+	return Compare(config.Since, config.Until, previous, current), nil
+}
+
+// loadRevision loads the components of the opencontrol directory at a git revision.
+// The workspace has no components when the directory does not exist at that revision.
+func loadRevision(opencontrolDir string, revision string) (common.Workspace, []error) {
+	dir, cleanup, err := gitrev.Checkout(opencontrolDir, revision)
+	if err != nil {
+		return nil, []error{err}
+	}
+	defer cleanup()
+	workspace := lib.NewWorkspace()
+	componentsDir := filepath.Join(dir, "components")
+	if _, err := os.Stat(componentsDir); os.IsNotExist(err) {
+		return workspace, nil
+	}
+	return workspace, workspace.LoadComponents(componentsDir)
+}
+
+// WriteJSON writes the changes as JSON.
+func (c Changes) WriteJSON(out io.Writer) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s\n", data)
+	return err
+}
+
+// WriteMarkdown writes the changes as Markdown, with a section per control.
+func (c Changes) WriteMarkdown(out io.Writer) {
+	fmt.Fprintf(out, "# Changes from %s to %s\n", c.Since, c.Until)
+	if len(c.Components) == 0 && len(c.Controls) == 0 {
+		fmt.Fprintf(out, "\nNo changes.\n")
+		return
+	}
+	if len(c.Components) > 0 {
+		fmt.Fprintf(out, "\n## Components\n\n")
+	}
+	for _, component := range c.Components {
+		fmt.Fprintf(out, "- %s component %s (%s)\n", strings.Title(component.Change), component.Name, component.Key)
+	}
+	for _, control := range c.Controls {
+		fmt.Fprintf(out, "\n## %s %s\n", control.Standard, control.Control)
+		for _, component := range control.Components {
+			fmt.Fprintf(out, "\n### %s (%s): %s\n\n", component.ComponentName, component.ComponentKey, component.Change)
+			if component.StatusFrom != nil || component.StatusTo != nil {
+				fmt.Fprintf(out, "- Status: %s → %s\n", statusText(component.StatusFrom), statusText(component.StatusTo))
+			}
+			writeTextChanges(out, "Narrative", component.Narratives)
+			writeTextChanges(out, "Parameter", component.Parameters)
+		}
+	}
+}
+
+func statusText(statuses []string) string {
+	if len(statuses) == 0 {
+		return "(none)"
+	}
+	return strings.Join(statuses, ", ")
+}
+
+func writeTextChanges(out io.Writer, title string, changes []TextChange) {
+	for _, change := range changes {
+		name := title
+		if change.Key != "" {
+			name += " " + change.Key
+		}
+		fmt.Fprintf(out, "- %s %s", name, change.Change)
+		switch change.Change {
+		case Added:
+			fmt.Fprintf(out, ":\n\n%s\n", quote(change.New))
+		case Removed:
+			fmt.Fprintf(out, ":\n\n%s\n", quote(change.Old))
+		default:
+			fmt.Fprintf(out, ":\n\n```diff\n%s```\n", change.Diff)
+		}
+	}
+}
+
+// quote formats the text as a Markdown block quote indented in a list item.
+func quote(text string) string {
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimRight("  > "+line, " ")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,227 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package changes
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/tools/textdiff"
+)
+
+const (
+	// Added is the change of something that only exists in the current workspace.
+	Added = "added"
+	// Removed is the change of something that only exists in the previous workspace.
+	Removed = "removed"
+	// Changed is the change of something that exists in both workspaces with differences.
+	Changed = "changed"
+)
+
+// Changes contains the changes of the documentation between two revisions.
+type Changes struct {
+	Since      string            `json:"since"`
+	Until      string            `json:"until"`
+	Components []ComponentChange `json:"components"`
+	Controls   []ControlChange   `json:"controls"`
+}
+
+// ComponentChange is an added or removed component.
+type ComponentChange struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Change string `json:"change"`
+}
+
+// ControlChange contains the changes of the components satisfying a control.
+type ControlChange struct {
+	Standard   string            `json:"standard"`
+	Control    string            `json:"control"`
+	Components []SatisfiesChange `json:"components"`
+}
+
+// SatisfiesChange contains the changes of how a component satisfies a control.
+type SatisfiesChange struct {
+	ComponentKey  string `json:"component_key"`
+	ComponentName string `json:"component_name"`
+	Change        string `json:"change"`
+	// StatusFrom and StatusTo are the implementation statuses when they changed.
+	StatusFrom []string     `json:"status_from,omitempty"`
+	StatusTo   []string     `json:"status_to,omitempty"`
+	Narratives []TextChange `json:"narratives,omitempty"`
+	Parameters []TextChange `json:"parameters,omitempty"`
+}
+
+// TextChange is an added, removed or changed narrative or parameter.
+type TextChange struct {
+	Key    string `json:"key,omitempty"`
+	Change string `json:"change"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+	// Diff is the unified diff of the old and new texts.
+	Diff string `json:"diff,omitempty"`
+}
+
+// satisfiesEntry is a satisfies item along with the component and the control it belongs to.
+type satisfiesEntry struct {
+	component common.Component
+	satisfies common.Satisfies
+}
+
+// Compare computes the changes between the previous and the current workspace. Controls are matched by their normalized
+// control key and keep the spelling of the current workspace.
+func Compare(since string, until string, previous common.Workspace, current common.Workspace) Changes {
+	changes := Changes{
+		Since:      since,
+		Until:      until,
+		Components: make([]ComponentChange, 0),
+		Controls:   make([]ControlChange, 0),
+	}
+	for _, component := range current.GetAllComponents() {
+		if _, found := previous.GetComponent(component.GetKey()); !found {
+			changes.Components = append(changes.Components, ComponentChange{component.GetKey(), component.GetName(), Added})
+		}
+	}
+	for _, component := range previous.GetAllComponents() {
+		if _, found := current.GetComponent(component.GetKey()); !found {
+			changes.Components = append(changes.Components, ComponentChange{component.GetKey(), component.GetName(), Removed})
+		}
+	}
+	sort.Slice(changes.Components, func(i, j int) bool {
+		return sortorder.NaturalLess(changes.Components[i].Key, changes.Components[j].Key)
+	})
+
+	oldEntries, newEntries := entries(previous), entries(current)
+	controls := make(map[string]*ControlChange)
+	add := func(satisfies common.Satisfies, change SatisfiesChange) {
+		key := controlKey(satisfies)
+		if _, found := controls[key]; !found {
+			controls[key] = &ControlChange{Standard: satisfies.GetStandardKey(), Control: satisfies.GetControlKey()}
+		}
+		controls[key].Components = append(controls[key].Components, change)
+	}
+	for key, newEntry := range newEntries {
+		oldEntry, found := oldEntries[key]
+		if !found {
+			add(newEntry.satisfies, compareSatisfies(since, until, newEntry.component, nil, newEntry.satisfies))
+			continue
+		}
+		if change := compareSatisfies(since, until, newEntry.component, oldEntry.satisfies, newEntry.satisfies); change.Change != "" {
+			add(newEntry.satisfies, change)
+		}
+	}
+	for key, oldEntry := range oldEntries {
+		if _, found := newEntries[key]; !found {
+			add(oldEntry.satisfies, compareSatisfies(since, until, oldEntry.component, oldEntry.satisfies, nil))
+		}
+	}
+	for _, control := range controls {
+		sort.Slice(control.Components, func(i, j int) bool {
+			return sortorder.NaturalLess(control.Components[i].ComponentKey, control.Components[j].ComponentKey)
+		})
+		changes.Controls = append(changes.Controls, *control)
+	}
+	sort.Slice(changes.Controls, func(i, j int) bool {
+		if changes.Controls[i].Standard != changes.Controls[j].Standard {
+			return sortorder.NaturalLess(changes.Controls[i].Standard, changes.Controls[j].Standard)
+		}
+		return sortorder.NaturalLess(changes.Controls[i].Control, changes.Controls[j].Control)
+	})
+	return changes
+}
+
+// entries returns the satisfies items of all the components by component, standard and normalized control.
+func entries(workspace common.Workspace) map[string]satisfiesEntry {
+	entries := make(map[string]satisfiesEntry)
+	for _, component := range workspace.GetAllComponents() {
+		for _, satisfies := range component.GetAllSatisfies() {
+			key := component.GetKey() + "@" + controlKey(satisfies)
+			if _, found := entries[key]; !found {
+				entries[key] = satisfiesEntry{component, satisfies}
+			}
+		}
+	}
+	return entries
+}
+
+func controlKey(satisfies common.Satisfies) string {
+	return satisfies.GetStandardKey() + "@" + controlkeys.Normalize(satisfies.GetStandardKey(), satisfies.GetControlKey())
+}
+
+// compareSatisfies compares how a component satisfies a control. The previous or current satisfies is nil when the
+// component does not satisfy the control at that revision. The change is empty when nothing changed.
+func compareSatisfies(since string, until string, component common.Component, previous common.Satisfies,
+	current common.Satisfies) SatisfiesChange {
+	change := SatisfiesChange{ComponentKey: component.GetKey(), ComponentName: component.GetName(), Change: Changed}
+	var oldStatuses, newStatuses []string
+	var oldNarratives, newNarratives, oldParameters, newParameters []common.Section
+	switch {
+	case previous == nil:
+		change.Change = Added
+	case current == nil:
+		change.Change = Removed
+	}
+	if previous != nil {
+		oldStatuses, oldNarratives, oldParameters = statuses(previous), previous.GetNarratives(), previous.GetParameters()
+	}
+	if current != nil {
+		newStatuses, newNarratives, newParameters = statuses(current), current.GetNarratives(), current.GetParameters()
+	}
+	if strings.Join(oldStatuses, ", ") != strings.Join(newStatuses, ", ") {
+		change.StatusFrom, change.StatusTo = oldStatuses, newStatuses
+	}
+	change.Narratives = compareSections(since, until, oldNarratives, newNarratives)
+	change.Parameters = compareSections(since, until, oldParameters, newParameters)
+	if change.Change == Changed && change.StatusTo == nil && change.StatusFrom == nil &&
+		len(change.Narratives) == 0 && len(change.Parameters) == 0 {
+		change.Change = ""
+	}
+	return change
+}
+
+// statuses returns all the implementation statuses of a satisfies item.
+func statuses(satisfies common.Satisfies) []string {
+	if statuses := satisfies.GetImplementationStatuses(); len(statuses) > 0 {
+		return statuses
+	}
+	if status := satisfies.GetImplementationStatus(); status != "" {
+		return []string{status}
+	}
+	return nil
+}
+
+// compareSections compares narratives or parameters by their keys.
+func compareSections(since string, until string, previous []common.Section, current []common.Section) []TextChange {
+	var changes []TextChange
+	oldTexts := make(map[string]string)
+	for _, section := range previous {
+		oldTexts[section.GetKey()] = strings.TrimSpace(section.GetText())
+	}
+	newKeys := make(map[string]bool)
+	for _, section := range current {
+		key, text := section.GetKey(), strings.TrimSpace(section.GetText())
+		newKeys[key] = true
+		oldText, found := oldTexts[key]
+		switch {
+		case !found:
+			changes = append(changes, TextChange{Key: key, Change: Added, New: text})
+		case oldText != text:
+			diff := textdiff.Unified(since, until, textdiff.Lines(oldText), textdiff.Lines(text))
+			changes = append(changes, TextChange{Key: key, Change: Changed, Old: oldText, New: text, Diff: diff})
+		}
+	}
+	for _, section := range previous {
+		if !newKeys[section.GetKey()] {
+			changes = append(changes, TextChange{Key: section.GetKey(), Change: Removed, Old: oldTexts[section.GetKey()]})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return sortorder.NaturalLess(changes[i].Key, changes[j].Key)
+	})
+	return changes
+}
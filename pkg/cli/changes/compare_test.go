@@ -0,0 +1,72 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package changes
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadComponents(t *testing.T, revision string) common.Workspace {
+	workspace := lib.NewWorkspace()
+	errs := workspace.LoadComponents(filepath.Join("..", "..", "..", "test", "fixtures", "changes_fixtures", revision, "components"))
+	require.Empty(t, errs)
+	return workspace
+}
+
+func TestCompare(t *testing.T) {
+	changes := Compare("v1", "v2", loadComponents(t, "previous"), loadComponents(t, "current"))
+	// Check that added and removed components are reported
+	assert.Equal(t, []ComponentChange{
+		{Key: "Legacy", Name: "Legacy Mainframe", Change: Removed},
+		{Key: "RDS", Name: "Amazon Relational Database Service", Change: Added},
+	}, changes.Components)
+	// Check that unchanged controls are not reported and that CM-02 matches CM-2
+	assert.Equal(t, []ControlChange{
+		{Standard: "NIST-800-53", Control: "AC-2", Components: []SatisfiesChange{
+			{ComponentKey: "Legacy", ComponentName: "Legacy Mainframe", Change: Removed, StatusFrom: []string{"complete"},
+				Narratives: []TextChange{{Change: Removed, Old: "Accounts are managed by hand."}}},
+			{ComponentKey: "RDS", ComponentName: "Amazon Relational Database Service", Change: Added, StatusTo: []string{"partial"},
+				Narratives: []TextChange{{Change: Added, New: "Database accounts are managed with IAM."}}},
+		}},
+		{Standard: "NIST-800-53", Control: "CM-02", Components: []SatisfiesChange{
+			{ComponentKey: "EC2", ComponentName: "Amazon Elastic Compute Cloud", Change: Changed,
+				StatusFrom: []string{"partial"}, StatusTo: []string{"complete"},
+				Narratives: []TextChange{
+					{Key: "a", Change: Changed,
+						Old:  "EC2 instances are built from a baseline image.\nThe image is reviewed every year.",
+						New:  "EC2 instances are built from a baseline image.\nThe image is reviewed every quarter.",
+						Diff: "--- v1\n+++ v2\n@@ -1,2 +1,2 @@\n EC2 instances are built from a baseline image.\n-The image is reviewed every year.\n+The image is reviewed every quarter.\n"},
+					{Key: "b", Change: Added, New: "Deviations from the baseline are reported."},
+				}},
+		}},
+		{Standard: "PCI-DSS-MAY-2015", Control: "2.1", Components: []SatisfiesChange{
+			{ComponentKey: "EC2", ComponentName: "Amazon Elastic Compute Cloud", Change: Removed, StatusFrom: []string{"planned"},
+				Narratives: []TextChange{{Change: Removed, Old: "Default accounts will be disabled."}}},
+		}},
+	}, changes.Controls)
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	changes := Compare("v1", "v2", loadComponents(t, "previous"), loadComponents(t, "current"))
+	var out bytes.Buffer
+	changes.WriteMarkdown(&out)
+	assert.Contains(t, out.String(), "# Changes from v1 to v2\n\n## Components\n\n"+
+		"- Removed component Legacy Mainframe (Legacy)\n- Added component Amazon Relational Database Service (RDS)\n")
+	assert.Contains(t, out.String(), "\n## NIST-800-53 CM-02\n\n### Amazon Elastic Compute Cloud (EC2): changed\n\n"+
+		"- Status: partial → complete\n- Narrative a changed:\n\n```diff\n--- v1\n+++ v2\n")
+	assert.Contains(t, out.String(), "- Narrative b added:\n\n  > Deviations from the baseline are reported.\n")
+
+	// Check that the absence of changes is reported
+	out.Reset()
+	Compare("v1", "v2", loadComponents(t, "current"), loadComponents(t, "current")).WriteMarkdown(&out)
+	assert.Equal(t, "# Changes from v1 to v2\n\nNo changes.\n", out.String())
+}
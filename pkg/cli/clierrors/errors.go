@@ -11,11 +11,14 @@ import (
 	"strings"
 )
 
-// CheckError is for a Single check failure
+// CheckError is for a Single check failure. It exits with the exit code of an ExitError, or 1 otherwise.
 func CheckError(err error) {
 	if err != nil {
 		if err != context.Canceled {
-			fmt.Fprintf(os.Stderr, fmt.Sprintf("An error occurred: %v\n", err))
+			fmt.Fprintf(os.Stderr, "An error occurred: %v\n", err)
+		}
+		if exitError, ok := err.(*ExitError); ok {
+			os.Exit(exitError.ExitCode())
 		}
 		os.Exit(1)
 	}
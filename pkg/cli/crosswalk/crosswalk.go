@@ -0,0 +1,269 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package crosswalk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/opencontrol/compliance-masonry/pkg/cli/clierrors"
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/tools/constants"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// Covered is the coverage of a control mapped to a documented control that covers all its requirements.
+	Covered = "covered"
+	// Partial is the coverage of a control only mapped to documented controls that cover some of its requirements.
+	Partial = "partial"
+	// Gap is the coverage of a control mapped to controls that are not documented.
+	Gap = "gap"
+	// Unmapped is the coverage of a control that no mapping relates to another standard.
+	Unmapped = "unmapped"
+)
+
+// NewCmdCrosswalk projects the documented controls onto the controls of another standard.
+func NewCmdCrosswalk(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "crosswalk",
+		Short: "Project the documented controls onto the controls of another standard",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunCrosswalk(out, cmd, args)
+			clierrors.CheckError(err)
+		},
+	}
+	cmd.Flags().StringP("opencontrol", "o", constants.DefaultOpenControlsFolder, "Set opencontrol directory")
+	cmd.Flags().String("to", "", "Standard to project the documented controls onto, e.g. ISO-27001")
+	cmd.Flags().StringP("format", "f", "markdown", "Output format: markdown or json")
+	return cmd
+}
+
+// RunCrosswalk runs crosswalk when specified in cli
+func RunCrosswalk(out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("too many arguments. expected none")
+	}
+	config := Config{
+		OpencontrolDir: cmd.Flag("opencontrol").Value.String(),
+		To:             cmd.Flag("to").Value.String(),
+	}
+	if config.To == "" {
+		return fmt.Errorf("the standard to project onto must be specified with --to")
+	}
+	format := cmd.Flag("format").Value.String()
+	if format != "markdown" && format != "json" {
+		return fmt.Errorf("unsupported format '%s'. expected markdown or json", format)
+	}
+	crosswalk, errs := ComputeCrosswalk(config)
+	if errs != nil && len(errs) > 0 {
+		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), 1)
+	}
+	if format == "json" {
+		return crosswalk.WriteJSON(out)
+	}
+	crosswalk.WriteMarkdown(out)
+	return nil
+}
+
+// Config contains the settings for how to compute the crosswalk
+type Config struct {
+	OpencontrolDir string
+	// To is the key of the standard to project onto.
+	To string
+}
+
+// Crosswalk contains the coverage of the controls of a standard inferred from the controls they are mapped to.
+type Crosswalk struct {
+	Standard string    `json:"standard"`
+	Summary  Summary   `json:"summary"`
+	Controls []Control `json:"controls"`
+}
+
+// Summary contains the number of controls of the standard by coverage.
+type Summary struct {
+	Controls       int     `json:"controls"`
+	Covered        int     `json:"covered"`
+	Partial        int     `json:"partial"`
+	Gaps           int     `json:"gaps"`
+	Unmapped       int     `json:"unmapped"`
+	PercentCovered float64 `json:"percent_covered"`
+}
+
+// Control contains the coverage of a control of the standard.
+type Control struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Coverage string `json:"coverage"`
+	// Mappings are the controls of other standards mapped to the control.
+	Mappings []common.ControlMapping `json:"mappings"`
+	// Satisfies are the satisfies entries of the mapped controls.
+	Satisfies []InferredSatisfies `json:"satisfies"`
+}
+
+// InferredSatisfies is a satisfies entry of a mapped control. Its narratives were not written for the control of
+// the standard, they are inferred from the mapping.
+type InferredSatisfies struct {
+	Inferred  bool                  `json:"inferred"`
+	Mapping   common.ControlMapping `json:"mapping"`
+	Component string                `json:"component"`
+	Statuses  []string              `json:"statuses"`
+	// Narratives are the texts of the narratives by narrative key.
+	Narratives []Narrative `json:"narratives"`
+}
+
+// Narrative is a narrative of a mapped control.
+type Narrative struct {
+	Key  string `json:"key,omitempty"`
+	Text string `json:"text"`
+}
+
+// ComputeCrosswalk loads the components, the standards and the mappings of the opencontrol directory and infers the
+// coverage of every control of the standard from the documented controls mapped to it.
+func ComputeCrosswalk(config Config) (Crosswalk, []error) {
+	workspace := lib.NewWorkspace()
+	var errs []error
+	errs = append(errs, workspace.LoadComponents(filepath.Join(config.OpencontrolDir, constants.DefaultComponentsFolder))...)
+	errs = append(errs, workspace.LoadStandards(filepath.Join(config.OpencontrolDir, constants.DefaultStandardsFolder))...)
+	errs = append(errs, workspace.LoadMappings(filepath.Join(config.OpencontrolDir, constants.DefaultMappingsFolder))...)
+	if len(errs) > 0 {
+		return Crosswalk{}, errs
+	}
+	return Project(workspace, config.To)
+}
+
+// Project infers the coverage of every control of a standard of the workspace from the documented controls mapped
+// to it.
+func Project(workspace common.Workspace, standardKey string) (Crosswalk, []error) {
+	standard, found := workspace.GetStandard(standardKey)
+	if !found {
+		return Crosswalk{}, []error{fmt.Errorf("Standard %s cannot be found in the workspace", standardKey)}
+	}
+	mappings := workspace.GetMappingsTo(standardKey)
+	crosswalk := Crosswalk{Standard: standardKey, Controls: make([]Control, 0)}
+	for _, controlKey := range standard.GetSortedControls() {
+		control := Control{
+			Key:       controlKey,
+			Name:      standard.GetControl(controlKey).GetName(),
+			Coverage:  Unmapped,
+			Mappings:  make([]common.ControlMapping, 0),
+			Satisfies: make([]InferredSatisfies, 0),
+		}
+		for _, mapping := range mappings {
+			if !controlkeys.Equal(standardKey, mapping.TargetControl, controlKey) {
+				continue
+			}
+			control.Mappings = append(control.Mappings, mapping)
+			if control.Coverage == Unmapped {
+				control.Coverage = Gap
+			}
+			verifications := workspace.GetAllVerificationsWith(mapping.SourceStandard, mapping.SourceControl)
+			for _, verification := range verifications {
+				control.Satisfies = append(control.Satisfies, inferSatisfies(mapping, verification))
+			}
+			switch {
+			case len(verifications) == 0:
+			case mapping.IsComplete():
+				control.Coverage = Covered
+			case control.Coverage != Covered:
+				control.Coverage = Partial
+			}
+		}
+		crosswalk.Controls = append(crosswalk.Controls, control)
+		crosswalk.Summary.add(control.Coverage)
+	}
+	if crosswalk.Summary.Controls > 0 {
+		crosswalk.Summary.PercentCovered = float64(crosswalk.Summary.Covered) * 100 / float64(crosswalk.Summary.Controls)
+	}
+	return crosswalk, nil
+}
+
+func inferSatisfies(mapping common.ControlMapping, verification common.Verification) InferredSatisfies {
+	satisfies := verification.SatisfiesData
+	inferred := InferredSatisfies{
+		Inferred:   true,
+		Mapping:    mapping,
+		Component:  verification.ComponentKey,
+		Statuses:   satisfies.GetImplementationStatuses(),
+		Narratives: make([]Narrative, 0),
+	}
+	if len(inferred.Statuses) == 0 && satisfies.GetImplementationStatus() != "" {
+		inferred.Statuses = []string{satisfies.GetImplementationStatus()}
+	}
+	for _, narrative := range satisfies.GetNarratives() {
+		inferred.Narratives = append(inferred.Narratives, Narrative{Key: narrative.GetKey(), Text: narrative.GetText()})
+	}
+	return inferred
+}
+
+func (summary *Summary) add(coverage string) {
+	summary.Controls++
+	switch coverage {
+	case Covered:
+		summary.Covered++
+	case Partial:
+		summary.Partial++
+	case Gap:
+		summary.Gaps++
+	default:
+		summary.Unmapped++
+	}
+}
+
+// WriteJSON writes the crosswalk as JSON.
+func (crosswalk Crosswalk) WriteJSON(out io.Writer) error {
+	data, err := json.MarshalIndent(crosswalk, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s\n", data)
+	return err
+}
+
+// WriteMarkdown writes the coverage of every control with the narratives of the mapped controls, labeled as
+// inferred.
+func (crosswalk Crosswalk) WriteMarkdown(out io.Writer) {
+	summary := crosswalk.Summary
+	fmt.Fprintf(out, "# Crosswalk to %s: %d controls\n\n", crosswalk.Standard, summary.Controls)
+	fmt.Fprintf(out, "- Covered: %d (%.1f%%)\n", summary.Covered, summary.PercentCovered)
+	fmt.Fprintf(out, "- Partial: %d\n", summary.Partial)
+	fmt.Fprintf(out, "- Gaps: %d\n", summary.Gaps)
+	fmt.Fprintf(out, "- Unmapped: %d\n", summary.Unmapped)
+	for _, control := range crosswalk.Controls {
+		fmt.Fprintf(out, "\n## %s %s: %s\n", control.Key, control.Name, control.Coverage)
+		for _, mapping := range control.Mappings {
+			fmt.Fprintf(out, "\n- Mapped to %s %s (%s)\n", mapping.SourceStandard, mapping.SourceControl, mapping.Relationship)
+		}
+		for _, satisfies := range control.Satisfies {
+			fmt.Fprintf(out, "\n### Inferred from %s %s, %s", satisfies.Mapping.SourceStandard,
+				satisfies.Mapping.SourceControl, satisfies.Component)
+			if len(satisfies.Statuses) > 0 {
+				fmt.Fprintf(out, " (%s)", joinStatuses(satisfies.Statuses))
+			}
+			fmt.Fprintln(out)
+			for _, narrative := range satisfies.Narratives {
+				if narrative.Key != "" {
+					fmt.Fprintf(out, "\n#### %s\n", narrative.Key)
+				}
+				fmt.Fprintf(out, "\n> %s\n", narrative.Text)
+			}
+		}
+	}
+}
+
+func joinStatuses(statuses []string) string {
+	text := ""
+	for i, status := range statuses {
+		if i > 0 {
+			text += ", "
+		}
+		text += status
+	}
+	return text
+}
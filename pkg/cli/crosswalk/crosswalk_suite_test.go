@@ -0,0 +1,17 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package crosswalk_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCrosswalk(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Crosswalk Suite")
+}
@@ -0,0 +1,79 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package crosswalk_test
+
+import (
+	. "github.com/opencontrol/compliance-masonry/pkg/cli/crosswalk"
+
+	"bytes"
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("Crosswalk", func() {
+	var (
+		config Config
+	)
+	BeforeEach(func() {
+		workingDir, _ := os.Getwd()
+		config = Config{
+			OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "crosswalk_fixtures"),
+			To:             "ISO-27001",
+		}
+	})
+	Context("When projecting onto a standard", func() {
+		It("should infer the coverage of every control from the mapped controls", func() {
+			crosswalk, errs := ComputeCrosswalk(config)
+			assert.Nil(GinkgoT(), errs)
+			assert.Equal(GinkgoT(), Summary{Controls: 4, Covered: 1, Partial: 1, Gaps: 1, Unmapped: 1, PercentCovered: 25},
+				crosswalk.Summary)
+			var coverage []string
+			for _, control := range crosswalk.Controls {
+				coverage = append(coverage, control.Key+" "+control.Coverage)
+			}
+			assert.Equal(GinkgoT(), []string{"A.5.1.1 unmapped", "A.9.2.1 covered", "A.9.2.3 gap", "A.12.1.2 partial"}, coverage)
+		})
+		It("should match the mapped controls whatever their spelling in the components", func() {
+			crosswalk, _ := ComputeCrosswalk(config)
+			satisfies := crosswalk.Controls[1].Satisfies
+			assert.Equal(GinkgoT(), 1, len(satisfies))
+			assert.True(GinkgoT(), satisfies[0].Inferred)
+			assert.Equal(GinkgoT(), "EC2", satisfies[0].Component)
+			assert.Equal(GinkgoT(), []string{"complete"}, satisfies[0].Statuses)
+			assert.Equal(GinkgoT(), []Narrative{{Text: "Accounts are managed with AWS IAM."}}, satisfies[0].Narratives)
+		})
+		It("should label the narratives as inferred", func() {
+			crosswalk, _ := ComputeCrosswalk(config)
+			var out bytes.Buffer
+			crosswalk.WriteMarkdown(&out)
+			assert.Contains(GinkgoT(), out.String(), "## A.9.2.1 User registration and de-registration: covered\n\n"+
+				"- Mapped to NIST-800-53 AC-2 (equivalent)\n\n"+
+				"### Inferred from NIST-800-53 AC-2, EC2 (complete)\n\n"+
+				"> Accounts are managed with AWS IAM.\n")
+			out.Reset()
+			assert.Nil(GinkgoT(), crosswalk.WriteJSON(&out))
+			var written map[string]interface{}
+			assert.Nil(GinkgoT(), json.Unmarshal(out.Bytes(), &written))
+			assert.Contains(GinkgoT(), out.String(), `"inferred": true`)
+		})
+		It("should project onto the source standard of the mappings", func() {
+			config.To = "NIST-800-53"
+			crosswalk, errs := ComputeCrosswalk(config)
+			assert.Nil(GinkgoT(), errs)
+			assert.Equal(GinkgoT(), 3, crosswalk.Summary.Gaps)
+		})
+	})
+	Context("When the standard cannot be found", func() {
+		It("should return an error", func() {
+			config.To = "CIS"
+			_, errs := ComputeCrosswalk(config)
+			assert.Equal(GinkgoT(), 1, len(errs))
+			assert.EqualError(GinkgoT(), errs[0], "Standard CIS cannot be found in the workspace")
+		})
+	})
+})
@@ -14,6 +14,13 @@ import (
 	"github.com/tg/gosortmap"
 )
 
+const (
+	// ExitCodeError is the exit code when the gap analysis cannot be computed.
+	ExitCodeError = 1
+	// ExitCodeThresholdFailed is the exit code when the gap analysis does not meet the thresholds.
+	ExitCodeThresholdFailed = 2
+)
+
 // NewCmdDiff provides a gap diff analysis.
 func NewCmdDiff(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
@@ -25,11 +32,22 @@ func NewCmdDiff(out io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringP("opencontrol", "o", constants.DefaultOpenControlsFolder, "Set opencontrol directory")
+	cmd.Flags().StringSlice("gaps", nil, "Effective statuses of the documented controls that count as missing, e.g. planned,none")
+	cmd.Flags().String("status-policy", "", "YAML file of the policy that rolls up the statuses of the components")
+	cmd.Flags().Float64("fail-under", 0, "Fail when the percentage of covered controls is under this value")
+	cmd.Flags().String("coverage", CoverageDocumented, "Controls covered for --fail-under: documented or complete")
+	cmd.Flags().Int("max-missing", -1, "Fail when more controls are missing")
+	cmd.Flags().Bool("parts", false, "List the parts of the controls that are not addressed by every component")
+	cmd.Flags().String("from", "", "Certification to upgrade from, to list the controls added by --to")
+	cmd.Flags().String("to", "", "Certification to upgrade to")
 	return cmd
 }
 
 // RunDiff runs diff when specified in cli
 func RunDiff(out io.Writer, cmd *cobra.Command, args []string) error {
+	if cmd.Flag("from").Value.String() != "" || cmd.Flag("to").Value.String() != "" {
+		return runUpgrade(out, cmd, args)
+	}
 	if len(args) == 0 {
 		return fmt.Errorf("certification type not specified")
 	}
@@ -40,14 +58,67 @@ func RunDiff(out io.Writer, cmd *cobra.Command, args []string) error {
 	config := Config{
 		Certification:  args[0],
 		OpencontrolDir: cmd.Flag("opencontrol").Value.String(),
+		StatusPolicy:   cmd.Flag("status-policy").Value.String(),
+	}
+	gaps, err := cmd.Flags().GetStringSlice("gaps")
+	if err != nil {
+		return err
+	}
+	config.Gaps = gaps
+	if config.FailUnder, err = cmd.Flags().GetFloat64("fail-under"); err != nil {
+		return err
+	}
+	if config.MaxMissing, err = cmd.Flags().GetInt("max-missing"); err != nil {
+		return err
+	}
+	if config.Parts, err = cmd.Flags().GetBool("parts"); err != nil {
+		return err
+	}
+	config.Coverage = cmd.Flag("coverage").Value.String()
+	if config.Coverage != CoverageDocumented && config.Coverage != CoverageComplete {
+		return fmt.Errorf("unsupported coverage '%s'. expected documented or complete", config.Coverage)
 	}
 	inventory, errs := ComputeGapAnalysis(config)
 	if errs != nil && len(errs) > 0 {
-		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), 1)
+		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), ExitCodeError)
+	}
+	for _, warning := range inventory.Warnings {
+		fmt.Fprintf(out, "Warning: %v\n", warning)
 	}
 	fmt.Fprintf(out, "\nNumber of missing controls: %d\n", len(inventory.MissingControlList))
 	for _, standardAndControl := range sortmap.ByKey(inventory.MissingControlList) {
 		fmt.Fprintf(out, "%s\n", standardAndControl.Key)
 	}
+	summary := inventory.Summary
+	fmt.Fprintf(out, "\nDocumented controls: %d of %d (%.1f%%)\n", summary.Documented, summary.Controls, summary.PercentDocumented)
+	fmt.Fprintf(out, "Complete controls: %d of %d (%.1f%%)\n", summary.Complete, summary.Controls, summary.PercentComplete)
+	if config.Parts {
+		inventory.WritePartGaps(out)
+	}
+	if errs := config.CheckThresholds(summary); len(errs) > 0 {
+		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), ExitCodeThresholdFailed)
+	}
+	return nil
+}
+
+// runUpgrade lists the controls that a certification adds to another one.
+func runUpgrade(out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("too many arguments. expected no certification type with --from and --to")
+	}
+	config := UpgradeConfig{
+		OpencontrolDir: cmd.Flag("opencontrol").Value.String(),
+		From:           cmd.Flag("from").Value.String(),
+		To:             cmd.Flag("to").Value.String(),
+		StatusPolicy:   cmd.Flag("status-policy").Value.String(),
+	}
+	if config.From == "" || config.To == "" {
+		return fmt.Errorf("both --from and --to certifications must be specified")
+	}
+	upgrade, errs := ComputeUpgrade(config)
+	if errs != nil && len(errs) > 0 {
+		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), ExitCodeError)
+	}
+	upgrade.WriteText(out)
 	return nil
 }
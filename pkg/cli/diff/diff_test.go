@@ -10,6 +10,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
 	"github.com/opencontrol/compliance-masonry/pkg/tests"
 )
 
@@ -34,4 +35,28 @@ var _ = Describe("Diff Commands", func() {
 			Eventually(output.Out.Contents).Should(ContainSubstring("Number of missing controls:"))
 		})
 	})
+	Describe("When the CLI is run with the `diff` command with thresholds", func() {
+		It("should exit with a distinct exit code when a threshold fails", func() {
+			output := masonry_test.Masonry(
+				"diff", "LATO", "--fail-under", "95",
+				"-o", filepath.Join("..", "..", "..", "test", "fixtures", "opencontrol_fixtures")).Wait(1 * time.Second)
+			Eventually(output).Should(gexec.Exit(2))
+			Eventually(output.Out.Contents).Should(ContainSubstring("Documented controls: 3 of 6 (50.0%)"))
+			Eventually(output.Err.Contents).Should(ContainSubstring("50.0% of the controls are documented, expected at least 95.0%"))
+		})
+		It("should succeed when the thresholds are met", func() {
+			output := masonry_test.Masonry(
+				"diff", "LATO", "--max-missing", "3",
+				"-o", filepath.Join("..", "..", "..", "test", "fixtures", "opencontrol_fixtures")).Wait(1 * time.Second)
+			Eventually(output).Should(gexec.Exit(0))
+		})
+	})
+	Describe("When the CLI is run with the `diff` command with two certifications", func() {
+		It("should print the controls added by the second certification", func() {
+			output := masonry_test.Masonry(
+				"diff", "--from", "LATO-base", "--to", "LATO",
+				"-o", filepath.Join("..", "..", "..", "test", "fixtures", "opencontrol_fixtures")).Wait(1 * time.Second)
+			Eventually(output.Out.Contents).Should(ContainSubstring("Controls added from LATO-base to LATO: 4 (2 already documented)"))
+		})
+	})
 })
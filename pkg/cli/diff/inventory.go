@@ -8,39 +8,77 @@ import (
 	"fmt"
 	"github.com/opencontrol/compliance-masonry/pkg/lib"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
 	"github.com/opencontrol/compliance-masonry/tools/certifications"
 )
 
 // Inventory maintains the inventory of all the controls within a given workspace.
+// Controls are matched by their normalized control key. The MissingControlList keeps the spelling of the certification.
+// Controls required by the certification that can not be found in the loaded standards are still part of the
+// MissingControlList (with a nil Control) and are reported in Warnings.
+// Documented controls are also part of the MissingControlList when their effective status is one of the gaps.
 type Inventory struct {
 	common.Workspace
 	masterControlList       map[string]common.Control
+	masterControlKeys       map[string]string
+	masterControlStatuses   map[string]string
+	gaps                    map[string]bool
 	actualSatisfiedControls map[string]common.Satisfies
 	MissingControlList      map[string]common.Control
+	Summary                 Summary
+	Warnings                []error
+	// PartGaps are only computed when Config.Parts is set.
+	PartGaps []PartGap
+}
+
+// Summary contains the counts and percentages of the certification controls.
+type Summary struct {
+	Controls int `json:"controls"`
+	// Documented is the number of controls that are not missing.
+	Documented        int     `json:"documented"`
+	Missing           int     `json:"missing"`
+	PercentDocumented float64 `json:"percent_documented"`
+	// Complete is the number of controls with the effective status complete.
+	Complete        int     `json:"complete"`
+	PercentComplete float64 `json:"percent_complete"`
 }
 
 // retrieveMasterControlsList will gather the list of controls needed for a given certification.
 func (i *Inventory) retrieveMasterControlsList() {
 	standardKeys := i.GetCertification().GetSortedStandards()
 	for _, standardKey := range standardKeys {
+		standard, standardFound := i.GetStandard(standardKey)
+		var controls controlkeys.Index
+		if standardFound {
+			controls = controlkeys.NewIndex(standard)
+		}
 		for _, controlKey := range i.GetCertification().GetControlKeysFor(standardKey) {
-			key := standardAndControlString(standardKey, controlKey)
+			key := normalizedStandardAndControlString(standardKey, controlKey)
 			if _, exists := i.masterControlList[key]; !exists {
-				standard, found := i.GetStandard(standardKey)
-				if !found {
-					continue
+				// Keep controls that can not be found so that they still count as missing.
+				i.masterControlList[key] = nil
+				if standardFound {
+					i.masterControlList[key], _ = controls.Get(controlKey)
 				}
-				i.masterControlList[key] = standard.GetControl(controlKey)
+				// Keep the spelling of the certification for display.
+				i.masterControlKeys[key] = standardAndControlString(standardKey, controlKey)
+				i.masterControlStatuses[key] = i.GetEffectiveStatus(standardKey, controlKey)
 			}
 		}
 	}
 }
 
 // findDocumentedControls will find the list of all documented controls found within the workspace.
+// Inherited controls are documented when the entry of the component they are inherited from can be resolved.
 func (i *Inventory) findDocumentedControls() {
 	for _, component := range i.GetAllComponents() {
 		for _, satisfiedControl := range component.GetAllSatisfies() {
-			key := standardAndControlString(satisfiedControl.GetStandardKey(), satisfiedControl.GetControlKey())
+			if !satisfiedControl.GetInheritedFrom().IsEmpty() {
+				if _, _, found := i.GetInheritedSatisfies(satisfiedControl); !found {
+					continue
+				}
+			}
+			key := normalizedStandardAndControlString(satisfiedControl.GetStandardKey(), satisfiedControl.GetControlKey())
 			if _, exists := i.actualSatisfiedControls[key]; !exists {
 				i.actualSatisfiedControls[key] = satisfiedControl
 			}
@@ -51,22 +89,90 @@ func (i *Inventory) findDocumentedControls() {
 // calculateNonDocumentedControls will compute the diff between the master list of controls and the documented controls.
 func (i *Inventory) calculateNonDocumentedControls() {
 	for standardAndControlKey, control := range i.masterControlList {
-		if _, exists := i.actualSatisfiedControls[standardAndControlKey]; !exists {
-			i.MissingControlList[standardAndControlKey] = control
+		_, exists := i.actualSatisfiedControls[standardAndControlKey]
+		if !exists || i.gaps[i.masterControlStatuses[standardAndControlKey]] {
+			i.MissingControlList[i.masterControlKeys[standardAndControlKey]] = control
 		}
 	}
 }
 
+// summarize counts the documented, missing and complete controls.
+func (i *Inventory) summarize() {
+	i.Summary.Controls = len(i.masterControlList)
+	i.Summary.Missing = len(i.MissingControlList)
+	i.Summary.Documented = i.Summary.Controls - i.Summary.Missing
+	for _, status := range i.masterControlStatuses {
+		if status == "complete" {
+			i.Summary.Complete++
+		}
+	}
+	if i.Summary.Controls > 0 {
+		i.Summary.PercentDocumented = float64(i.Summary.Documented) * 100 / float64(i.Summary.Controls)
+		i.Summary.PercentComplete = float64(i.Summary.Complete) * 100 / float64(i.Summary.Controls)
+	}
+}
+
 // standardAndControlString makes a string from the standard and the control.
 // This is helpful for functions that want to create unique keys consistently.
 func standardAndControlString(standard string, control string) string {
 	return standard + "@" + control
 }
 
+// normalizedStandardAndControlString makes a string from the standard and the normalized control.
+// This is helpful for functions that want to match the different spellings of the same control.
+func normalizedStandardAndControlString(standard string, control string) string {
+	return standardAndControlString(standard, controlkeys.Normalize(standard, control))
+}
+
 // Config contains the settings for how to compute the gap analysis
 type Config struct {
 	Certification  string
 	OpencontrolDir string
+	// Gaps are the effective statuses of the documented controls that still count as missing, e.g. planned.
+	Gaps []string
+	// StatusPolicy is the YAML file of the policy that rolls up the statuses of the components.
+	StatusPolicy string
+	// FailUnder is the minimum percentage of covered controls. Zero disables the check.
+	FailUnder float64
+	// Coverage is how the covered controls are counted: CoverageDocumented or CoverageComplete.
+	Coverage string
+	// MaxMissing is the maximum number of missing controls. A negative number disables the check.
+	MaxMissing int
+	// Parts compares the narrative keys of the components with the parts of the controls.
+	Parts bool
+}
+
+const (
+	// CoverageDocumented counts the controls that are not missing.
+	CoverageDocumented = "documented"
+	// CoverageComplete counts the controls with the effective status complete.
+	CoverageComplete = "complete"
+)
+
+// CheckThresholds returns the thresholds of the config that the summary does not meet.
+func (config Config) CheckThresholds(summary Summary) []error {
+	var errs []error
+	if config.FailUnder > 0 {
+		coverage := summary.PercentDocumented
+		if config.Coverage == CoverageComplete {
+			coverage = summary.PercentComplete
+		}
+		if coverage < config.FailUnder {
+			errs = append(errs, fmt.Errorf("%.1f%% of the controls are %s, expected at least %.1f%%",
+				coverage, config.coverage(), config.FailUnder))
+		}
+	}
+	if config.MaxMissing >= 0 && summary.Missing > config.MaxMissing {
+		errs = append(errs, fmt.Errorf("%d controls are missing, expected at most %d", summary.Missing, config.MaxMissing))
+	}
+	return errs
+}
+
+func (config Config) coverage() string {
+	if config.Coverage == "" {
+		return CoverageDocumented
+	}
+	return config.Coverage
 }
 
 // ComputeGapAnalysis will compute the gap analysis and return the inventory of the controls for the
@@ -83,19 +189,36 @@ func ComputeGapAnalysis(config Config) (Inventory, []error) {
 	i := Inventory{
 		Workspace:               workspace,
 		masterControlList:       make(map[string]common.Control),
+		masterControlKeys:       make(map[string]string),
+		masterControlStatuses:   make(map[string]string),
+		gaps:                    make(map[string]bool),
 		actualSatisfiedControls: make(map[string]common.Satisfies),
 		MissingControlList:      make(map[string]common.Control),
 	}
 	if i.GetCertification() == nil || i.GetAllComponents() == nil {
 		return Inventory{}, []error{fmt.Errorf("Unable to load data in %s for certification %s", config.OpencontrolDir, config.Certification)}
 	}
-
+	if err := lib.LoadStatusPolicy(workspace, config.StatusPolicy); err != nil {
+		return Inventory{}, []error{err}
+	}
+	for _, gap := range config.Gaps {
+		i.gaps[gap] = true
+	}
+	// Warn about standards and controls of the certification that are not in the workspace,
+	// about components satisfying withdrawn controls and about inherited controls that cannot be resolved
+	i.Warnings = append(lib.CheckCertification(workspace), lib.CheckWithdrawnControls(workspace)...)
+	i.Warnings = append(i.Warnings, lib.CheckInheritedControls(workspace)...)
 	// Gather list of all controls for certification
 	i.retrieveMasterControlsList()
 	// Find the documented controls.
 	i.findDocumentedControls()
 	// Calculate the Missing controls / Non documented
 	i.calculateNonDocumentedControls()
+	// Count the controls
+	i.summarize()
+	if config.Parts {
+		i.findPartGaps()
+	}
 
 	return i, nil
 }
@@ -51,6 +51,98 @@ var _ = Describe("Inventory", func() {
 				assert.Equal(GinkgoT(), 3, len(i.MissingControlList))
 			})
 		})
+		Context("When the effective status of some documented controls is a gap", func() {
+			It("should count them as missing", func() {
+				config := Config{
+					OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures"),
+					Certification:  "LATO",
+					Gaps:           []string{"partial"},
+				}
+				i, err := ComputeGapAnalysis(config)
+				assert.Nil(GinkgoT(), err)
+				assert.Equal(GinkgoT(), 6, len(i.MissingControlList))
+				assert.Contains(GinkgoT(), i.MissingControlList, "NIST-800-53@CM-2")
+			})
+		})
+		Context("When we summarize the gap analysis", func() {
+			It("should count the documented and complete controls", func() {
+				config := Config{
+					OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures"),
+					Certification:  "LATO",
+				}
+				i, err := ComputeGapAnalysis(config)
+				assert.Nil(GinkgoT(), err)
+				assert.Equal(GinkgoT(), Summary{Controls: 6, Documented: 3, Missing: 3, PercentDocumented: 50}, i.Summary)
+			})
+		})
+		Context("When the status policy cannot be loaded", func() {
+			It("should return an error", func() {
+				config := Config{
+					OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures"),
+					Certification:  "LATO",
+					StatusPolicy:   "missing.yaml",
+				}
+				_, err := ComputeGapAnalysis(config)
+				assert.Equal(GinkgoT(), 1, len(err))
+			})
+		})
+		Context("When the controls are spelled differently in the certification, standard and components", func() {
+			It("should match the controls and keep the spelling of the certification", func() {
+				config := Config{
+					OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_spellings"),
+					Certification:  "LATO",
+				}
+				i, err := ComputeGapAnalysis(config)
+				assert.Nil(GinkgoT(), err)
+				assert.Equal(GinkgoT(), 1, len(i.MissingControlList))
+				assert.Contains(GinkgoT(), i.MissingControlList, "NIST-800-53@CM-2")
+				assert.Empty(GinkgoT(), i.Warnings)
+			})
+		})
+		Context("When the parts of the controls are compared with the narratives", func() {
+			It("should list the parts missing from some components and the unknown parts", func() {
+				config := Config{
+					OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_rev5"),
+					Certification:  "LATO",
+					Parts:          true,
+				}
+				i, err := ComputeGapAnalysis(config)
+				assert.Nil(GinkgoT(), err)
+				assert.Equal(GinkgoT(), []PartGap{
+					{Control: "NIST-800-53-rev5@AC-2", Part: "b", AddressedBy: []string{"Web"}, MissingFrom: []string{"Database"}},
+					{Control: "NIST-800-53-rev5@AC-2", Part: "e", AddressedBy: []string{"Database"}, Unknown: true},
+				}, i.PartGaps)
+			})
+		})
+		Context("When a component satisfies a withdrawn control", func() {
+			It("should warn about it", func() {
+				config := Config{
+					OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_rev5"),
+					Certification:  "LATO",
+				}
+				i, err := ComputeGapAnalysis(config)
+				assert.Nil(GinkgoT(), err)
+				assert.Equal(GinkgoT(), []error{errors.New("Component Database satisfies control AC-2 (10) of the standard NIST-800-53-rev5, however that control was withdrawn. Use AC-2 instead.")}, i.Warnings)
+			})
+		})
+		Context("When a component inherits controls from another component", func() {
+			It("should count the resolved controls as covered and warn about the others", func() {
+				config := Config{
+					OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_inherited"),
+					Certification:  "LATO",
+				}
+				i, err := ComputeGapAnalysis(config)
+				assert.Nil(GinkgoT(), err)
+				assert.Equal(GinkgoT(), 2, len(i.MissingControlList))
+				assert.Contains(GinkgoT(), i.MissingControlList, "NIST-800-53@PE-3")
+				assert.Contains(GinkgoT(), i.MissingControlList, "NIST-800-53@SC-7")
+				assert.Equal(GinkgoT(), Summary{Controls: 4, Documented: 2, Missing: 2, PercentDocumented: 50, Complete: 2, PercentComplete: 50}, i.Summary)
+				assert.Equal(GinkgoT(), []error{
+					errors.New("Component App inherits control PE-3 of the standard NIST-800-53 from the component DataCenter, however that component does not exist."),
+					errors.New("Component App inherits control SC-7 of the standard NIST-800-53 from the component AWS, however AWS no longer satisfies control SC-7 of the standard NIST-800-53."),
+				}, i.Warnings)
+			})
+		})
 		Context("When there are controls specified in the certification and we have documented them", func() {
 			It("should return no missing controls", func() {
 				config := Config{
@@ -64,3 +156,28 @@ var _ = Describe("Inventory", func() {
 		})
 	})
 })
+
+var _ = Describe("Thresholds", func() {
+	summary := Summary{Controls: 6, Documented: 3, Missing: 3, PercentDocumented: 50}
+	Context("When the thresholds are met", func() {
+		It("should return no errors", func() {
+			config := Config{FailUnder: 50, MaxMissing: 3}
+			assert.Empty(GinkgoT(), config.CheckThresholds(summary))
+		})
+	})
+	Context("When the thresholds are disabled", func() {
+		It("should return no errors", func() {
+			config := Config{MaxMissing: -1}
+			assert.Empty(GinkgoT(), config.CheckThresholds(summary))
+		})
+	})
+	Context("When the thresholds are not met", func() {
+		It("should return an error for each threshold", func() {
+			config := Config{FailUnder: 95, Coverage: CoverageComplete, MaxMissing: 2}
+			assert.Equal(GinkgoT(), []error{
+				errors.New("0.0% of the controls are complete, expected at least 95.0%"),
+				errors.New("3 controls are missing, expected at most 2"),
+			}, config.CheckThresholds(summary))
+		})
+	})
+})
@@ -0,0 +1,115 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package diff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/parts"
+)
+
+// PartGap is a part of a certification control that is not addressed by all the components satisfying the control,
+// or a narrative key of those components that is not a part of the control.
+type PartGap struct {
+	// Control is the standard and control key, spelled as in the certification.
+	Control string
+	Part    string
+	// AddressedBy are the keys of the components with a narrative for the part.
+	AddressedBy []string
+	// MissingFrom are the keys of the components without a narrative for the part.
+	MissingFrom []string
+	// Unknown is true when the part is not a part of the control. AddressedBy are the components using it.
+	Unknown bool
+}
+
+// findPartGaps compares the narrative keys of the components with the parts of the certification controls.
+// Controls without parts and controls that no component satisfies are skipped.
+func (i *Inventory) findPartGaps() {
+	seen := make(map[string]bool)
+	for _, standardKey := range i.GetCertification().GetSortedStandards() {
+		standard, found := i.GetStandard(standardKey)
+		if !found {
+			continue
+		}
+		index := controlkeys.NewIndex(standard)
+		for _, controlKey := range i.GetCertification().GetControlKeysFor(standardKey) {
+			key := normalizedStandardAndControlString(standardKey, controlKey)
+			control, found := index.Get(controlKey)
+			if seen[key] || !found || len(control.GetParts()) == 0 {
+				continue
+			}
+			seen[key] = true
+			missingFrom := make(map[string][]string)
+			unknownIn := make(map[string][]string)
+			var componentKeys, unknownParts []string
+			for _, verification := range i.GetAllVerificationsWith(standardKey, controlKey) {
+				componentKeys = append(componentKeys, verification.ComponentKey)
+				missing, unknown := parts.Match(control.GetParts(), verification.SatisfiesData.GetNarratives())
+				for _, part := range missing {
+					missingFrom[part] = append(missingFrom[part], verification.ComponentKey)
+				}
+				for _, part := range unknown {
+					if len(unknownIn[part]) == 0 {
+						unknownParts = append(unknownParts, part)
+					}
+					unknownIn[part] = append(unknownIn[part], verification.ComponentKey)
+				}
+			}
+			for _, part := range control.GetParts() {
+				if len(missingFrom[part.Key]) == 0 {
+					continue
+				}
+				i.PartGaps = append(i.PartGaps, PartGap{
+					Control:     standardAndControlString(standardKey, controlKey),
+					Part:        part.Key,
+					AddressedBy: without(componentKeys, missingFrom[part.Key]),
+					MissingFrom: missingFrom[part.Key],
+				})
+			}
+			for _, part := range unknownParts {
+				i.PartGaps = append(i.PartGaps, PartGap{
+					Control:     standardAndControlString(standardKey, controlKey),
+					Part:        part,
+					AddressedBy: unknownIn[part],
+					Unknown:     true,
+				})
+			}
+		}
+	}
+}
+
+// WritePartGaps writes the part gaps, one per line.
+func (i Inventory) WritePartGaps(out io.Writer) {
+	fmt.Fprintf(out, "\nParts not addressed by every component: %d\n", len(i.PartGaps))
+	for _, gap := range i.PartGaps {
+		switch {
+		case gap.Unknown:
+			fmt.Fprintf(out, "%s %s: unknown part in %s\n", gap.Control, gap.Part, strings.Join(gap.AddressedBy, ", "))
+		case len(gap.AddressedBy) == 0:
+			fmt.Fprintf(out, "%s %s: missing from %s\n", gap.Control, gap.Part, strings.Join(gap.MissingFrom, ", "))
+		default:
+			fmt.Fprintf(out, "%s %s: missing from %s (addressed by %s)\n", gap.Control, gap.Part,
+				strings.Join(gap.MissingFrom, ", "), strings.Join(gap.AddressedBy, ", "))
+		}
+	}
+}
+
+// without returns the values that are not in the excluded values.
+func without(values []string, excluded []string) []string {
+	var remaining []string
+	for _, value := range values {
+		found := false
+		for _, excludedValue := range excluded {
+			found = found || value == excludedValue
+		}
+		if !found {
+			remaining = append(remaining, value)
+		}
+	}
+	return remaining
+}
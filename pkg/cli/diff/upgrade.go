@@ -0,0 +1,165 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package diff
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	libcertifications "github.com/opencontrol/compliance-masonry/pkg/lib/certifications"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/tools/certifications"
+)
+
+// UpgradeConfig contains the settings for how to compare two certifications.
+type UpgradeConfig struct {
+	OpencontrolDir string
+	// From is the certification of the system today.
+	From string
+	// To is the certification the system moves to.
+	To string
+	// StatusPolicy is the YAML file of the policy that rolls up the statuses of the components.
+	StatusPolicy string
+}
+
+// Upgrade contains the controls that the To certification requires on top of the From certification,
+// grouped by standard and family.
+type Upgrade struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Families []FamilyUpgrade `json:"families"`
+	// Added is the number of added controls.
+	Added int `json:"added"`
+	// Documented is the number of added controls that components already satisfy.
+	Documented int `json:"documented"`
+}
+
+// FamilyUpgrade contains the added controls of a family of a standard.
+type FamilyUpgrade struct {
+	Standard string         `json:"standard"`
+	Family   string         `json:"family"`
+	Controls []AddedControl `json:"controls"`
+}
+
+// AddedControl is a control required by the To certification only.
+type AddedControl struct {
+	Control string `json:"control"`
+	Name    string `json:"name"`
+	// Components are the names of the components that already satisfy the control.
+	Components      []string `json:"components"`
+	EffectiveStatus string   `json:"effective_status"`
+}
+
+// Documented returns true when a component already satisfies the control.
+func (c AddedControl) Documented() bool {
+	return len(c.Components) > 0
+}
+
+// ComputeUpgrade computes the controls required by the To certification that are not required by the From
+// certification. Controls are matched by their normalized control key and keep the spelling of the To certification.
+func ComputeUpgrade(config UpgradeConfig) (Upgrade, []error) {
+	fromPath, errs := certifications.GetCertification(config.OpencontrolDir, config.From)
+	if fromPath == "" {
+		return Upgrade{}, errs
+	}
+	toPath, errs := certifications.GetCertification(config.OpencontrolDir, config.To)
+	if toPath == "" {
+		return Upgrade{}, errs
+	}
+	from, err := libcertifications.Load(fromPath)
+	if err != nil {
+		return Upgrade{}, []error{fmt.Errorf("Unable to load the certification %s: %v", config.From, err)}
+	}
+	workspace, _ := lib.LoadData(config.OpencontrolDir, toPath)
+	if workspace.GetCertification() == nil || workspace.GetAllComponents() == nil {
+		return Upgrade{}, []error{fmt.Errorf("Unable to load data in %s for certification %s", config.OpencontrolDir, config.To)}
+	}
+	if err := lib.LoadStatusPolicy(workspace, config.StatusPolicy); err != nil {
+		return Upgrade{}, []error{err}
+	}
+
+	fromControls := make(map[string]bool)
+	for _, standardKey := range from.GetSortedStandards() {
+		for _, controlKey := range from.GetControlKeysFor(standardKey) {
+			fromControls[normalizedStandardAndControlString(standardKey, controlKey)] = true
+		}
+	}
+
+	upgrade := Upgrade{From: from.GetKey(), To: workspace.GetCertification().GetKey()}
+	for _, standardKey := range workspace.GetCertification().GetSortedStandards() {
+		standard, standardFound := workspace.GetStandard(standardKey)
+		families := make(map[string]*FamilyUpgrade)
+		for _, controlKey := range workspace.GetCertification().GetControlKeysFor(standardKey) {
+			key := normalizedStandardAndControlString(standardKey, controlKey)
+			if fromControls[key] {
+				continue
+			}
+			// Only count a control once, whatever its spellings in the certification.
+			fromControls[key] = true
+			added := AddedControl{
+				Control:         controlKey,
+				Components:      make([]string, 0),
+				EffectiveStatus: workspace.GetEffectiveStatus(standardKey, controlKey),
+			}
+			family := ""
+			if standardFound {
+				if control, found := controlkeys.NewIndex(standard).Get(controlKey); found {
+					added.Name, family = control.GetName(), control.GetFamily()
+				}
+			}
+			for _, verification := range workspace.GetAllVerificationsWith(standardKey, controlKey) {
+				if component, found := workspace.GetComponent(verification.ComponentKey); found {
+					added.Components = append(added.Components, component.GetName())
+				}
+			}
+			sort.Strings(added.Components)
+			if _, found := families[family]; !found {
+				families[family] = &FamilyUpgrade{Standard: standardKey, Family: family}
+			}
+			families[family].Controls = append(families[family].Controls, added)
+			upgrade.Added++
+			if added.Documented() {
+				upgrade.Documented++
+			}
+		}
+		var familyKeys []string
+		for family := range families {
+			familyKeys = append(familyKeys, family)
+		}
+		sort.Sort(sortorder.Natural(familyKeys))
+		for _, family := range familyKeys {
+			controls := families[family].Controls
+			sort.Slice(controls, func(i, j int) bool {
+				return sortorder.NaturalLess(controls[i].Control, controls[j].Control)
+			})
+			upgrade.Families = append(upgrade.Families, *families[family])
+		}
+	}
+	return upgrade, nil
+}
+
+// WriteText writes the added controls grouped by family.
+func (u Upgrade) WriteText(out io.Writer) {
+	fmt.Fprintf(out, "Controls added from %s to %s: %d (%d already documented)\n", u.From, u.To, u.Added, u.Documented)
+	for _, family := range u.Families {
+		name := family.Family
+		if name == "" {
+			name = "(no family)"
+		}
+		fmt.Fprintf(out, "\n%s %s\n", family.Standard, name)
+		for _, control := range family.Controls {
+			if control.Documented() {
+				fmt.Fprintf(out, "%s: documented by %s (%s)\n", control.Control, strings.Join(control.Components, ", "),
+					control.EffectiveStatus)
+			} else {
+				fmt.Fprintf(out, "%s: missing\n", control.Control)
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package diff_test
+
+import (
+	. "github.com/opencontrol/compliance-masonry/pkg/cli/diff"
+
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("Upgrade", func() {
+	var (
+		config UpgradeConfig
+	)
+	BeforeEach(func() {
+		workingDir, _ := os.Getwd()
+		config = UpgradeConfig{
+			OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures"),
+			From:           "LATO-base",
+			To:             "LATO",
+		}
+	})
+	Context("When a certification adds controls to another one", func() {
+		It("should list the added controls by family along with the components satisfying them", func() {
+			upgrade, err := ComputeUpgrade(config)
+			assert.Nil(GinkgoT(), err)
+			assert.Equal(GinkgoT(), 4, upgrade.Added)
+			assert.Equal(GinkgoT(), 2, upgrade.Documented)
+			assert.Equal(GinkgoT(), []FamilyUpgrade{
+				{Standard: "NIST-800-53", Family: "AC", Controls: []AddedControl{
+					{Control: "AC-6", Name: "Least Privilege", Components: []string{}, EffectiveStatus: "missing"},
+				}},
+				{Standard: "NIST-800-53", Family: "CM", Controls: []AddedControl{
+					{Control: "CM-2", Name: "Baseline Configuration", Components: []string{"Amazon Elastic Compute Cloud"},
+						EffectiveStatus: "partial"},
+				}},
+				{Standard: "PCI-DSS-MAY-2015", Family: "1", Controls: []AddedControl{
+					{Control: "1.1.1", Name: "A formal process for approving and testing all network connections and changes to the firewall and router configurations",
+						Components: []string{}, EffectiveStatus: "missing"},
+				}},
+				{Standard: "PCI-DSS-MAY-2015", Family: "2", Controls: []AddedControl{
+					{Control: "2.1", Name: "Always change vendor-supplied defaults and remove or disable unnecessary default accounts before installing a system on the network.",
+						Components: []string{"Amazon Elastic Compute Cloud"}, EffectiveStatus: "partial"},
+				}},
+			}, upgrade.Families)
+		})
+		It("should write the added controls as text", func() {
+			upgrade, _ := ComputeUpgrade(config)
+			var out bytes.Buffer
+			upgrade.WriteText(&out)
+			assert.Contains(GinkgoT(), out.String(), "Controls added from LATO-base to LATO: 4 (2 already documented)\n")
+			assert.Contains(GinkgoT(), out.String(), "\nNIST-800-53 AC\nAC-6: missing\n")
+		})
+	})
+	Context("When the certifications are the same", func() {
+		It("should list no controls", func() {
+			config.From = "LATO"
+			upgrade, err := ComputeUpgrade(config)
+			assert.Nil(GinkgoT(), err)
+			assert.Equal(GinkgoT(), 0, upgrade.Added)
+			assert.Empty(GinkgoT(), upgrade.Families)
+		})
+	})
+	Context("When a certification does not exist", func() {
+		It("should return an error", func() {
+			config.To = "Unknown"
+			_, err := ComputeUpgrade(config)
+			assert.Equal(GinkgoT(), 1, len(err))
+		})
+	})
+})
@@ -39,6 +39,7 @@ func NewCmdDocsGitBook(out io.Writer) *cobra.Command {
 	cmd.Flags().StringP("opencontrol", "o", constants.DefaultOpenControlsFolder, "Set opencontrol directory")
 	cmd.Flags().StringP("export", "e", constants.DefaultExportsFolder, "Sets the export directory")
 	cmd.Flags().StringP("markdown", "m", constants.DefaultMarkdownFolder, "Sets the markdown directory")
+	cmd.Flags().String("status-policy", "", "YAML file of the policy that rolls up the statuses of the components")
 	return cmd
 }
 
@@ -56,6 +57,7 @@ func RunGitBook(out io.Writer, cmd *cobra.Command, args []string) error {
 		OpencontrolDir: cmd.Flag("opencontrol").Value.String(),
 		ExportPath:     cmd.Flag("export").Value.String(),
 		MarkdownPath:   cmd.Flag("markdown").Value.String(),
+		StatusPolicy:   cmd.Flag("status-policy").Value.String(),
 	}
 	warning, errMessages := MakeGitbook(config)
 	if warning != "" {
@@ -81,8 +83,20 @@ func MakeGitbook(config gitbook.Config) (string, []error) {
 		warning = "Warning: markdown directory does not exist"
 	}
 	config.Certification = certificationPath
-	if err := config.BuildGitbook(); err != nil {
-		return warning, err
+	certificationWarnings, errs := config.BuildGitbook()
+	for _, certificationWarning := range certificationWarnings {
+		warning = appendWarning(warning, fmt.Sprintf("Warning: %v", certificationWarning))
+	}
+	if errs != nil {
+		return warning, errs
 	}
 	return warning, nil
 }
+
+// appendWarning adds a new line of warning to the existing warnings.
+func appendWarning(warning string, newWarning string) string {
+	if warning == "" {
+		return newWarning
+	}
+	return warning + "\n" + newWarning
+}
@@ -72,7 +72,7 @@ var _ = Describe("Doc Tests", func() {
 				MarkdownPath:   filepath.Join("..", "..", "..", "test", "fixtures", "opencontrol_fixtures_with_markdown", "markdowns"),
 			},
 			"",
-			[]error{fmt.Errorf("Error: `%s` does not exist\nUse one of the following:\nLATO", filepath.Join("..", "..", "..", "test", "fixtures", "opencontrol_fixtures_with_markdown", "certifications", "LAT.yaml"))},
+			[]error{fmt.Errorf("Error: `%s` does not exist\nDid you mean one of the following:\nLATO", filepath.Join("..", "..", "..", "test", "fixtures", "opencontrol_fixtures_with_markdown", "certifications", "LAT.yaml"))},
 		),
 
 		Entry(
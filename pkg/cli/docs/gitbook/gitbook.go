@@ -19,6 +19,8 @@ type Config struct {
 	Certification  string
 	ExportPath     string
 	MarkdownPath   string
+	// StatusPolicy is the YAML file of the policy that rolls up the statuses of the components.
+	StatusPolicy string
 }
 
 // OpenControlGitBook struct is an extension of models.OpenControl that adds
@@ -50,12 +52,17 @@ func exportLink(text string, location string) string {
 	return fmt.Sprintf("* [%s](%s)\n", text, location)
 }
 
-// BuildGitbook entry point for creating gitbook
-func (config Config) BuildGitbook() []error {
+// BuildGitbook entry point for creating gitbook.
+// The first returned slice contains warnings about the certification that did not prevent the gitbook from being
+// created, such as standards or controls that can not be found in the workspace.
+func (config Config) BuildGitbook() ([]error, []error) {
 	var errs []error
 	openControlData, err := lib.LoadData(config.OpencontrolDir, config.Certification)
 	if err != nil && len(err) > 0 {
-		return append(errs, err...)
+		return nil, append(errs, err...)
+	}
+	if err := lib.LoadStatusPolicy(openControlData, config.StatusPolicy); err != nil {
+		return nil, append(errs, err)
 	}
 	openControl := OpenControlGitBook{
 		openControlData,
@@ -63,13 +70,15 @@ func (config Config) BuildGitbook() []error {
 		config.ExportPath,
 		fs.OSUtil{},
 	}
+	warnings := append(lib.CheckCertification(openControlData), lib.CheckWithdrawnControls(openControlData)...)
+	warnings = append(warnings, lib.CheckInheritedControls(openControlData)...)
 	openControl.FSUtil.Mkdirs(config.ExportPath)
 	openControl.FSUtil.Mkdirs(filepath.Join(config.ExportPath, "components"))
 	openControl.FSUtil.Mkdirs(filepath.Join(config.ExportPath, "standards"))
 	if err := openControl.buildSummaries(); err != nil {
-		return append(errs, err)
+		return warnings, append(errs, err)
 	}
 	openControl.exportComponents()
 	openControl.exportStandards()
-	return nil
+	return warnings, nil
 }
@@ -6,9 +6,12 @@ package gitbook
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"path/filepath"
 
+	"github.com/fvbommel/sortorder"
 	"github.com/opencontrol/compliance-masonry/internal/constants"
 	"github.com/opencontrol/compliance-masonry/internal/utils"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
@@ -40,6 +43,24 @@ func (openControl *OpenControlGitBook) getNarrative(narrative common.Section, te
 	return text
 }
 
+func (openControl *OpenControlGitBook) getInherited(text string, satisfies common.Satisfies) string {
+	inheritedFrom := satisfies.GetInheritedFrom()
+	if inheritedFrom.IsEmpty() {
+		return text
+	}
+	provider, providerSatisfies, found := openControl.GetInheritedSatisfies(satisfies)
+	if !found {
+		standardKey, controlKey := inheritedFrom.GetKeys(satisfies.GetStandardKey(), satisfies.GetControlKey())
+		return fmt.Sprintf("%s\n##### Inherited from %s\nNo narrative found for the combination of component %s, standard %s and control %s\n",
+			text, inheritedFrom.ComponentKey, inheritedFrom.ComponentKey, standardKey, controlKey)
+	}
+	text = fmt.Sprintf("%s\n##### Inherited from %s (%s %s)\n", text, provider.GetName(), providerSatisfies.GetStandardKey(), providerSatisfies.GetControlKey())
+	for _, narrative := range providerSatisfies.GetNarratives() {
+		text = openControl.getNarrative(narrative, text)
+	}
+	return text
+}
+
 func (openControl *OpenControlGitBook) getParameters(text string, parameters []common.Section) string {
 	if len(parameters) > 0 {
 		text = fmt.Sprintf("%s\n##### Parameters:\n", text)
@@ -93,6 +114,44 @@ func (openControl *OpenControlGitBook) getControlOrigin(text string, controlOrig
 	return text
 }
 
+func (openControl *OpenControlGitBook) getParts(text string, parts []common.ControlPart, indent string) string {
+	for _, part := range parts {
+		text = fmt.Sprintf("%s%s* %s. %s\n", text, indent, part.Key, part.Text)
+		text = openControl.getParts(text, part.Parts, indent+"  ")
+	}
+	return text
+}
+
+func (openControl *OpenControlGitBook) getCertificationRequirements(text string, control *ControlGitbook) string {
+	certification := openControl.GetCertification()
+	if certification == nil {
+		return text
+	}
+	requirements := certification.GetControlRequirements(control.standardKey, control.controlKey)
+	if requirements.IsEmpty() {
+		return text
+	}
+	text = fmt.Sprintf("%s\n#### %s Requirements\n", text, certification.GetKey())
+	if requirements.Guidance != "" {
+		text = fmt.Sprintf("%s%s\n", text, requirements.Guidance)
+	}
+	if len(requirements.Parameters) > 0 {
+		text = fmt.Sprintf("%s\n##### Parameters:\n", text)
+	}
+	var parameterKeys []string
+	for parameterKey := range requirements.Parameters {
+		parameterKeys = append(parameterKeys, parameterKey)
+	}
+	sort.Sort(sortorder.Natural(parameterKeys))
+	for _, parameterKey := range parameterKeys {
+		text = fmt.Sprintf("%s\n###### %s\n%s\n", text, parameterKey, requirements.Parameters[parameterKey])
+	}
+	if requirements.Notes != "" {
+		text = fmt.Sprintf("%s\n##### Notes:\n%s\n", text, requirements.Notes)
+	}
+	return text
+}
+
 func (openControl *OpenControlGitBook) exportControl(control *ControlGitbook) (string, string) {
 	key := masonryutil.FileNameHandler(fmt.Sprintf("%s-%s", control.standardKey, control.controlKey))
 	text := fmt.Sprintf("# %s\n## %s\n", key, control.GetName())
@@ -100,11 +159,25 @@ func (openControl *OpenControlGitBook) exportControl(control *ControlGitbook) (s
 		text += "#### Description\n"
 		text += control.GetDescription()
 	}
+	if control.IsWithdrawn() {
+		text += "\n#### Withdrawn\n"
+		if len(control.GetIncorporatedInto()) > 0 {
+			text = fmt.Sprintf("%sIncorporated into %s.\n", text, strings.Join(control.GetIncorporatedInto(), ", "))
+		}
+	}
+	if len(control.GetParts()) > 0 {
+		text += "\n#### Statement\n"
+		text = openControl.getParts(text, control.GetParts(), "")
+	}
+	text = openControl.getCertificationRequirements(text, control)
 	selectJustifications := openControl.GetAllVerificationsWith(control.standardKey, control.controlKey)
 	// In the case that no information was found period for the standard and control
 	if len(selectJustifications) == 0 {
 		errorText := fmt.Sprintf("No information found for the combination of standard %s and control %s", control.standardKey, control.controlKey)
 		text = fmt.Sprintf("%s\n%s\n", text, errorText)
+	} else {
+		effectiveStatus := openControl.GetEffectiveStatus(control.standardKey, control.controlKey)
+		text = fmt.Sprintf("%s\n#### Effective Status: %s\n", text, effectiveStatus)
 	}
 	for _, justification := range selectJustifications {
 		component, found := openControl.GetComponent(justification.ComponentKey)
@@ -119,7 +192,12 @@ func (openControl *OpenControlGitBook) exportControl(control *ControlGitbook) (s
 
 		text = openControl.getControlOrigin(text, justification.SatisfiesData.GetControlOrigin())
 
-		text = openControl.getNarratives(justification.SatisfiesData.GetNarratives(), text, control)
+		// Inherited controls can be documented by the component they are inherited from only.
+		narratives := justification.SatisfiesData.GetNarratives()
+		if len(narratives) > 0 || justification.SatisfiesData.GetInheritedFrom().IsEmpty() {
+			text = openControl.getNarratives(narratives, text, control)
+		}
+		text = openControl.getInherited(text, justification.SatisfiesData)
 		text = openControl.getCoveredBy(text, justification)
 	}
 	return filepath.Join(control.exportPath, key+".md"), text
@@ -39,6 +39,8 @@ var exportControlTests = []exportControlTest{
 'The organization develops, documents, and maintains under configuration
 control, a current baseline configuration of the information system.'
 
+#### Effective Status: partial
+
 #### Amazon Elastic Compute Cloud
 
 ##### Responsible Role: AWS Staff
@@ -52,6 +54,149 @@ Justification in narrative form A for CM-2
 Justification in narrative form B for CM-2
 Covered By:
 * [Amazon Elastic Compute Cloud - EC2 Verification 1](../components/EC2.md)
+`,
+	},
+	// Check that the data of the certification for the control is exported
+	{
+		filepath.Join("..", "..", "..", "..", "test", "fixtures", "opencontrol_fixtures"),
+		filepath.Join("..", "..", "..", "..", "test", "fixtures", "certification_fixtures", "LATO-guidance.yaml"),
+		"NIST-800-53",
+		"CM-2",
+		"NIST-800-53-CM-2.md",
+		`# NIST-800-53-CM-2
+## Baseline Configuration
+#### Description
+'The organization develops, documents, and maintains under configuration
+control, a current baseline configuration of the information system.'
+
+#### LATO-guidance Requirements
+The service provider reviews the baseline configuration at least annually.
+
+##### Parameters:
+
+###### b (1)
+at least annually
+
+##### Notes:
+Applies to all production hosts.
+
+#### Effective Status: partial
+
+#### Amazon Elastic Compute Cloud
+
+##### Responsible Role: AWS Staff
+
+##### Control Origin: shared
+
+##### a
+Justification in narrative form A for CM-2
+
+##### b
+Justification in narrative form B for CM-2
+Covered By:
+* [Amazon Elastic Compute Cloud - EC2 Verification 1](../components/EC2.md)
+`,
+	},
+	// Check that the parts of the statement of a control are exported
+	{
+		filepath.Join("..", "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_rev5"),
+		filepath.Join("..", "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_rev5", "certifications", "LATO.yaml"),
+		"NIST-800-53-rev5",
+		"AC-2",
+		"NIST-800-53-rev5-AC-2.md",
+		`# NIST-800-53-rev5-AC-2
+## Account Management
+#### Description
+Manage the system accounts.
+#### Statement
+* a. Define and document the types of accounts allowed and specifically prohibited for use within the system;
+* b. Assign account managers;
+* d. Specify:
+  * 1. Authorized users of the system;
+  * 2. Group and role membership; and
+
+#### Effective Status: partial
+
+#### Database
+
+##### a
+Database accounts are defined in the access policy.
+
+##### d.1
+Only the application users can connect to the database.
+
+##### e
+Accounts are reviewed every quarter.
+
+#### Web
+
+##### a
+Accounts are managed through the central identity provider.
+
+##### b
+Each team lead manages the accounts of the team.
+
+##### d
+The identity provider groups define the authorized users and their roles.
+`,
+	},
+	// Check that withdrawn controls are marked with the controls that replace them
+	{
+		filepath.Join("..", "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_rev5"),
+		filepath.Join("..", "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_rev5", "certifications", "LATO.yaml"),
+		"NIST-800-53-rev5",
+		"AC-2 (10)",
+		"NIST-800-53-rev5-AC-2_10.md",
+		`# NIST-800-53-rev5-AC-2_10
+## Shared and Group Account Credential Change
+
+#### Withdrawn
+Incorporated into AC-2.
+
+#### Effective Status: complete
+
+#### Database
+
+No narrative found for the combination of standard NIST-800-53-rev5 and control AC-2 (10)
+`,
+	},
+	// Check that inherited controls are documented with the narrative of the component they are inherited from
+	{
+		filepath.Join("..", "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_inherited"),
+		filepath.Join("..", "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_inherited", "certifications", "LATO.yaml"),
+		"NIST-800-53",
+		"PE-2",
+		"NIST-800-53-PE-2.md",
+		`# NIST-800-53-PE-2
+## Physical Access Authorizations
+
+#### Effective Status: complete
+
+#### Amazon Web Services
+Data center access is restricted to approved staff.
+
+#### Application
+
+##### Inherited from Amazon Web Services (NIST-800-53 PE-02)
+Data center access is restricted to approved staff.
+`,
+	},
+	// Check that inherited controls that cannot be resolved are reported
+	{
+		filepath.Join("..", "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_inherited"),
+		filepath.Join("..", "..", "..", "..", "test", "fixtures", "opencontrol_fixtures_inherited", "certifications", "LATO.yaml"),
+		"NIST-800-53",
+		"SC-7",
+		"NIST-800-53-SC-7.md",
+		`# NIST-800-53-SC-7
+## Boundary Protection
+
+#### Effective Status: unknown
+
+#### Application
+
+##### Inherited from AWS
+No narrative found for the combination of component AWS, standard NIST-800-53 and control SC-7
 `,
 	},
 }
@@ -7,6 +7,7 @@ package gitbook
 import (
 	"fmt"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
 	"path/filepath"
 	"strings"
 
@@ -64,9 +65,14 @@ func (openControl *OpenControlGitBook) buildStandardsSummaries() (string, *map[s
 		if !found {
 			continue
 		}
+		controls := controlkeys.NewIndex(standard)
 		// Go through all the controls for the certification.
 		controlKeys := openControl.GetCertification().GetControlKeysFor(standardKey)
 		for _, controlKey := range controlKeys {
+			// Use the spelling of the standard so that the links match the exported control pages.
+			if key, found := controls.Find(controlKey); found {
+				controlKey = key
+			}
 			var controlSummary string
 			controlSummary, familyFileName, familySummaryMap =
 				openControl.buildStandardsSummary(standardKey, controlKey, standard, familyFileName,
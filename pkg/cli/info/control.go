@@ -0,0 +1,268 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package info
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/opencontrol/compliance-masonry/pkg/cli/clierrors"
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/tools/constants"
+	"github.com/opencontrol/compliance-masonry/tools/suggest"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdControl shows everything that is documented about a single control.
+func NewCmdControl(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "control <standard> <control>",
+		Short: "Get everything documented about a control",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunControl(out, cmd, args)
+			clierrors.CheckError(err)
+		},
+	}
+	cmd.Flags().StringP("opencontrol", "o", constants.DefaultOpenControlsFolder, "Set opencontrol directory")
+	cmd.Flags().StringP("certification", "c", "", "Certification to check the control against")
+	cmd.Flags().StringP("format", "f", "text", "Output format: text or json")
+	cmd.Flags().String("status-policy", "", "YAML file of the policy that rolls up the statuses of the components")
+	return cmd
+}
+
+// RunControl runs control when specified in cli
+func RunControl(out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("expected a standard and a control")
+	}
+	if cmd.Flag("certification").Value.String() == "" {
+		return fmt.Errorf("certification type not specified")
+	}
+	config := Config{
+		Certification:  cmd.Flag("certification").Value.String(),
+		OpencontrolDir: cmd.Flag("opencontrol").Value.String(),
+		StatusPolicy:   cmd.Flag("status-policy").Value.String(),
+	}
+	format := cmd.Flag("format").Value.String()
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format '%s'. expected text or json", format)
+	}
+	details, errs := FindControl(config, args[0], args[1])
+	if errs != nil && len(errs) > 0 {
+		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), 1)
+	}
+	if format == "json" {
+		return details.WriteJSON(out)
+	}
+	details.WriteText(out)
+	return nil
+}
+
+// ControlDetails contains everything documented about a control.
+type ControlDetails struct {
+	Standard    string `json:"standard"`
+	Control     string `json:"control"`
+	Name        string `json:"name"`
+	Family      string `json:"family"`
+	Description string `json:"description"`
+	// Certification is the certification the control is checked against.
+	Certification string `json:"certification"`
+	// Required is true when the certification requires the control.
+	Required bool `json:"required"`
+	// EffectiveStatus rolls up the statuses of all the components.
+	EffectiveStatus string             `json:"effective_status"`
+	Components      []ComponentControl `json:"components"`
+}
+
+// ComponentControl contains how a component satisfies the control.
+type ComponentControl struct {
+	Key             string `json:"key"`
+	Name            string `json:"name"`
+	ResponsibleRole string `json:"responsible_role"`
+	// ControlKey is the control key as spelled in the component.
+	ControlKey     string                `json:"control_key"`
+	Statuses       []string              `json:"implementation_statuses"`
+	ControlOrigins []string              `json:"control_origins"`
+	Narratives     []SectionDetails      `json:"narratives"`
+	Parameters     []SectionDetails      `json:"parameters"`
+	CoveredBy      []VerificationDetails `json:"covered_by"`
+}
+
+// SectionDetails is a narrative or a parameter.
+type SectionDetails struct {
+	Key  string `json:"key,omitempty"`
+	Text string `json:"text"`
+}
+
+// VerificationDetails is a covered_by verification resolved in the workspace.
+type VerificationDetails struct {
+	ComponentKey    string `json:"component_key"`
+	ComponentName   string `json:"component_name"`
+	VerificationKey string `json:"verification_key"`
+	Name            string `json:"name"`
+	Path            string `json:"path"`
+	Type            string `json:"type"`
+	// Found is false when the verification cannot be found in the workspace.
+	Found bool `json:"found"`
+}
+
+// FindControl is a function that collects everything documented about a control of a standard.
+func FindControl(config Config, standardKey string, controlKey string) (ControlDetails, []error) {
+	i, errs := loadInventory(config)
+	if errs != nil {
+		return ControlDetails{}, errs
+	}
+	standard, found := i.GetStandard(standardKey)
+	if !found {
+		message := fmt.Sprintf("Standard %s cannot be found in the workspace.", standardKey)
+		return ControlDetails{}, []error{fmt.Errorf("%s", suggest.Append(message, standardKey, lib.GetStandardKeys(i)))}
+	}
+	key, found := controlkeys.NewIndex(standard).Find(controlKey)
+	if !found {
+		message := fmt.Sprintf("Control %s cannot be found in the standard %s.", controlKey, standardKey)
+		return ControlDetails{}, []error{fmt.Errorf("%s", suggest.Append(message, controlKey, standard.GetSortedControls()))}
+	}
+	control := standard.GetControl(key)
+	details := ControlDetails{
+		Standard:        standardKey,
+		Control:         key,
+		Name:            control.GetName(),
+		Family:          control.GetFamily(),
+		Description:     strings.TrimSpace(control.GetDescription()),
+		Certification:   i.GetCertification().GetKey(),
+		EffectiveStatus: i.GetEffectiveStatus(standardKey, key),
+		Components:      make([]ComponentControl, 0),
+	}
+	for _, certificationControlKey := range i.GetCertification().GetControlKeysFor(standardKey) {
+		details.Required = details.Required || controlkeys.Equal(standardKey, certificationControlKey, key)
+	}
+	for _, verification := range i.GetAllVerificationsWith(standardKey, key) {
+		component, found := i.GetComponent(verification.ComponentKey)
+		if !found {
+			continue
+		}
+		details.Components = append(details.Components, componentControl(i, component, verification.SatisfiesData))
+	}
+	return details, nil
+}
+
+func componentControl(workspace common.Workspace, component common.Component, satisfies common.Satisfies) ComponentControl {
+	details := ComponentControl{
+		Key:             component.GetKey(),
+		Name:            component.GetName(),
+		ResponsibleRole: component.GetResponsibleRole(),
+		ControlKey:      satisfies.GetControlKey(),
+		Statuses:        nonNil(implementationStatuses(satisfies)),
+		ControlOrigins:  nonNil(controlOrigins(satisfies)),
+		Narratives:      sections(satisfies.GetNarratives()),
+		Parameters:      sections(satisfies.GetParameters()),
+		CoveredBy:       make([]VerificationDetails, 0),
+	}
+	for _, coveredBy := range satisfies.GetCoveredBy() {
+		// In case the component key is missing, the verification belongs to the component itself.
+		componentKey := coveredBy.ComponentKey
+		if componentKey == "" {
+			componentKey = component.GetKey()
+		}
+		verification := VerificationDetails{ComponentKey: componentKey, VerificationKey: coveredBy.VerificationKey}
+		if coveringComponent, found := workspace.GetComponent(componentKey); found {
+			verification.ComponentName = coveringComponent.GetName()
+			for _, reference := range *coveringComponent.GetVerifications() {
+				if reference.Key == coveredBy.VerificationKey {
+					verification.Name, verification.Path, verification.Type = reference.Name, reference.Path, reference.Type
+					verification.Found = true
+				}
+			}
+		}
+		details.CoveredBy = append(details.CoveredBy, verification)
+	}
+	return details
+}
+
+func sections(sections []common.Section) []SectionDetails {
+	details := make([]SectionDetails, 0, len(sections))
+	for _, section := range sections {
+		details = append(details, SectionDetails{Key: section.GetKey(), Text: strings.TrimSpace(section.GetText())})
+	}
+	return details
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// WriteJSON writes the control details as JSON.
+func (d ControlDetails) WriteJSON(out io.Writer) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s\n", data)
+	return err
+}
+
+// WriteText writes the control details as text.
+func (d ControlDetails) WriteText(out io.Writer) {
+	fmt.Fprintf(out, "# %s %s: %s\n", d.Standard, d.Control, d.Name)
+	fmt.Fprintf(out, "Family: %s\n", d.Family)
+	required := "no"
+	if d.Required {
+		required = "yes"
+	}
+	fmt.Fprintf(out, "Required by %s: %s\n", d.Certification, required)
+	fmt.Fprintf(out, "Effective status: %s\n", d.EffectiveStatus)
+	if d.Description != "" {
+		fmt.Fprintf(out, "\n## Description\n%s\n", d.Description)
+	}
+	if len(d.Components) == 0 {
+		fmt.Fprintf(out, "\nNo component satisfies this control.\n")
+	}
+	for _, component := range d.Components {
+		fmt.Fprintf(out, "\n## %s (%s)\n", component.Name, component.Key)
+		if component.ControlKey != d.Control {
+			fmt.Fprintf(out, "Control key: %s\n", component.ControlKey)
+		}
+		if component.ResponsibleRole != "" {
+			fmt.Fprintf(out, "Responsible role: %s\n", component.ResponsibleRole)
+		}
+		fmt.Fprintf(out, "Implementation statuses: %s\n", strings.Join(component.Statuses, ", "))
+		if len(component.ControlOrigins) > 0 {
+			fmt.Fprintf(out, "Control origins: %s\n", strings.Join(component.ControlOrigins, ", "))
+		}
+		writeSections(out, "Narrative", component.Narratives)
+		writeSections(out, "Parameter", component.Parameters)
+		if len(component.CoveredBy) > 0 {
+			fmt.Fprintf(out, "\n### Covered by\n")
+		}
+		for _, verification := range component.CoveredBy {
+			if !verification.Found {
+				fmt.Fprintf(out, "- %s (%s): not found\n", verification.VerificationKey, verification.ComponentKey)
+				continue
+			}
+			fmt.Fprintf(out, "- %s - %s: %s", verification.ComponentName, verification.Name, verification.Path)
+			if verification.Type != "" {
+				fmt.Fprintf(out, " (%s)", verification.Type)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+}
+
+func writeSections(out io.Writer, title string, sections []SectionDetails) {
+	for _, section := range sections {
+		if section.Key != "" {
+			fmt.Fprintf(out, "\n### %s %s\n%s\n", title, section.Key, section.Text)
+		} else {
+			fmt.Fprintf(out, "\n### %s\n%s\n", title, section.Text)
+		}
+	}
+}
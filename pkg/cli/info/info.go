@@ -11,6 +11,8 @@ import (
 	"github.com/opencontrol/compliance-masonry/pkg/cli/clierrors"
 	"github.com/opencontrol/compliance-masonry/pkg/lib"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/query"
 	"github.com/opencontrol/compliance-masonry/tools/certifications"
 	"github.com/opencontrol/compliance-masonry/tools/constants"
 	"github.com/spf13/cobra"
@@ -29,7 +31,9 @@ func NewCmdInfo(out io.Writer) *cobra.Command {
 	}
 	cmd.Flags().StringP("opencontrol", "o", constants.DefaultOpenControlsFolder, "Set opencontrol directory")
 	cmd.Flags().StringP("implementation-status", "i", "", "implementation_status to search for")
-	// cmd.Flags().StringP("query", "q", "", "arbitrary query")
+	cmd.Flags().StringP("query", "q", "", "query on the satisfied controls, e.g. 'status = partial and not has_narrative'")
+	cmd.AddCommand(NewCmdStats(out))
+	cmd.AddCommand(NewCmdControl(out))
 	return cmd
 }
 
@@ -48,7 +52,6 @@ func RunInfo(out io.Writer, cmd *cobra.Command, args []string) error {
 	}
 
 	// different types of searches can be done here.
-	// Currently, only implementation_status queries are implemented.
 	if cmd.Flag("implementation-status").Value.String() != "" {
 		inventory, errs := FindImplementationStatus(config, cmd.Flag("implementation-status").Value.String())
 		if errs != nil && len(errs) > 0 {
@@ -59,17 +62,16 @@ func RunInfo(out io.Writer, cmd *cobra.Command, args []string) error {
 			fmt.Fprintf(out, "%s\n", control.Key)
 		}
 	}
-	// // This is how we might do an arbitrary query
-	// if cmd.Flag("query").Value.String() != "" {
-	// 	inventory, errs := InfoQuery(config, cmd.Flag("query").Value.String())
-	// 	if errs != nil && len(errs) > 0 {
-	// 		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), 1)
-	// 	}
-	// 	fmt.Fprintf(out, "# Query result for: %s\n", cmd.Flag("query").Value.String())
-	// 	for _, control := range sortmap.ByKey(inventory.SatisfiesMap) {
-	// 		fmt.Fprintf(out, "%s\n", control.Key)
-	// 	}
-	// }
+	if cmd.Flag("query").Value.String() != "" {
+		inventory, errs := InfoQuery(config, cmd.Flag("query").Value.String())
+		if errs != nil && len(errs) > 0 {
+			return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), 1)
+		}
+		fmt.Fprintf(out, "# Query result for: %s\n", cmd.Flag("query").Value.String())
+		for _, control := range sortmap.ByKey(inventory.SatisfiesMap) {
+			fmt.Fprintf(out, "%s\n", control.Key)
+		}
+	}
 	return nil
 }
 
@@ -77,6 +79,8 @@ func RunInfo(out io.Writer, cmd *cobra.Command, args []string) error {
 type Config struct {
 	Certification  string
 	OpencontrolDir string
+	// StatusPolicy is the YAML file of the policy that rolls up the statuses of the components.
+	StatusPolicy string
 }
 
 // ComponentsInventory is where we store the data that we are returning.
@@ -89,6 +93,50 @@ type ComponentsInventory struct {
 // FindImplementationStatus is a function that finds satisfied controls which have an
 // implementation_status of statustype.
 func FindImplementationStatus(config Config, statustype string) (ComponentsInventory, []error) {
+	i, errs := loadInventory(config)
+	if errs != nil {
+		return ComponentsInventory{}, errs
+	}
+
+	// Different spellings of the same control are only listed once, with the first spelling found.
+	found := make(map[string]bool)
+	for _, component := range i.ComponentList {
+		for _, satisfiedControl := range component.GetAllSatisfies() {
+			for _, status := range satisfiedControl.GetImplementationStatuses() {
+				if status == statustype {
+					normalizedKey := component.GetName() + "@" +
+						controlkeys.Normalize(satisfiedControl.GetStandardKey(), satisfiedControl.GetControlKey())
+					if !found[normalizedKey] {
+						found[normalizedKey] = true
+						i.SatisfiesMap[component.GetName()+"@"+satisfiedControl.GetControlKey()] = satisfiedControl
+					}
+				}
+			}
+		}
+	}
+	return i, nil
+}
+
+// InfoQuery is a function that finds satisfied controls matching the query.
+// See query.Query for the syntax of the query.
+func InfoQuery(config Config, q string) (ComponentsInventory, []error) {
+	i, errs := loadInventory(config)
+	if errs != nil {
+		return ComponentsInventory{}, errs
+	}
+	verifications, err := query.Find(i.Workspace, q)
+	if err != nil {
+		return ComponentsInventory{}, []error{fmt.Errorf("Invalid query: %v", err)}
+	}
+	for _, verification := range verifications {
+		component, _ := i.GetComponent(verification.ComponentKey)
+		i.SatisfiesMap[component.GetName()+"@"+verification.SatisfiesData.GetControlKey()] = verification.SatisfiesData
+	}
+	return i, nil
+}
+
+// loadInventory loads the workspace of the certification into an empty inventory.
+func loadInventory(config Config) (ComponentsInventory, []error) {
 	// Initialize inventory with certification
 	certificationPath, errs := certifications.GetCertification(config.OpencontrolDir, config.Certification)
 	if certificationPath == "" || errs != nil {
@@ -98,6 +146,9 @@ func FindImplementationStatus(config Config, statustype string) (ComponentsInven
 	if errs != nil {
 		return ComponentsInventory{}, errs
 	}
+	if err := lib.LoadStatusPolicy(workspace, config.StatusPolicy); err != nil {
+		return ComponentsInventory{}, []error{err}
+	}
 	i := ComponentsInventory{
 		Workspace:    workspace,
 		SatisfiesMap: make(map[string]common.Satisfies),
@@ -107,23 +158,5 @@ func FindImplementationStatus(config Config, statustype string) (ComponentsInven
 	if i.GetCertification() == nil || i.ComponentList == nil {
 		return ComponentsInventory{}, []error{fmt.Errorf("Unable to load data in %s for certification %s", config.OpencontrolDir, config.Certification)}
 	}
-
-	for _, component := range i.ComponentList {
-		for _, satisfiedControl := range component.GetAllSatisfies() {
-			for _, status := range satisfiedControl.GetImplementationStatuses() {
-				if status == statustype {
-					key := component.GetName() + "@" + satisfiedControl.GetControlKey()
-					if _, exists := i.SatisfiesMap[key]; !exists {
-						i.SatisfiesMap[key] = satisfiedControl
-					}
-				}
-			}
-		}
-	}
 	return i, nil
 }
-
-// // This is where we would implement the query parsing and searching
-// func InfoQuery(config Config, query string) (ComponentsInventory, []error) {
-// 	magic query dust here
-// }
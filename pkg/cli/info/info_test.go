@@ -7,6 +7,7 @@ package info_test
 import (
 	. "github.com/opencontrol/compliance-masonry/pkg/cli/info"
 
+	"bytes"
 	"errors"
 	. "github.com/onsi/ginkgo"
 	"github.com/stretchr/testify/assert"
@@ -74,4 +75,137 @@ var _ = Describe("Info", func() {
 			})
 		})
 	})
+	Describe("Querying", func() {
+		var (
+			config Config
+		)
+		BeforeEach(func() {
+			workingDir, _ := os.Getwd()
+			config = Config{
+				OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures"),
+				Certification:  "LATO",
+			}
+		})
+		Context("When we query for inherited controls with a narrative", func() {
+			It("should only find one in our test data", func() {
+				i, err := InfoQuery(config, "control_origin = inherited and has_narrative")
+				assert.Nil(GinkgoT(), err)
+				assert.Equal(GinkgoT(), []string{"Amazon Elastic Compute Cloud@2.1"}, keys(i))
+			})
+		})
+		Context("When the query is invalid", func() {
+			It("should return an error", func() {
+				i, err := InfoQuery(config, "status = ")
+				assert.Equal(GinkgoT(), []error{errors.New("Invalid query: unexpected end of query")}, err)
+				assert.Equal(GinkgoT(), 0, len(i.SatisfiesMap))
+			})
+		})
+	})
+	Describe("Statistics", func() {
+		var (
+			config Config
+		)
+		BeforeEach(func() {
+			workingDir, _ := os.Getwd()
+			config = Config{
+				OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures"),
+				Certification:  "LATO",
+			}
+		})
+		Context("When we compute the statistics of a certification", func() {
+			It("should break down the certification controls", func() {
+				stats, err := ComputeStats(config)
+				assert.Nil(GinkgoT(), err)
+				assert.Equal(GinkgoT(), 6, stats.Controls)
+				assert.Equal(GinkgoT(), []Group{
+					{Name: "partial", Controls: 3, Percent: 50},
+					{Name: "missing", Controls: 3, Percent: 50},
+				}, stats.ByStatus)
+				assert.Equal(GinkgoT(), []string{"1", "2", "AC", "CM"}, groupNames(stats.ByFamily))
+				assert.Equal(GinkgoT(), []string{"(none)", "inherited", "shared"}, groupNames(stats.ByControlOrigin))
+				assert.Equal(GinkgoT(), []string{"(none)", "AWS Staff"}, groupNames(stats.ByResponsibleRole))
+				assert.Equal(GinkgoT(), []string{"(none)", "EC2"}, groupNames(stats.ByComponent))
+			})
+			It("should write the statistics as CSV", func() {
+				stats, _ := ComputeStats(config)
+				var out bytes.Buffer
+				assert.Nil(GinkgoT(), stats.WriteCSV(&out))
+				assert.Contains(GinkgoT(), out.String(), "breakdown,name,controls,percent,complete,percent_complete\n"+
+					"Status,partial,3,50.0,0,0.0\n")
+				assert.Contains(GinkgoT(), out.String(), "Component,EC2,3,50.0,0,0.0\n")
+			})
+		})
+		Context("When the certification does not exist", func() {
+			It("should return an error", func() {
+				config.Certification = "Unknown"
+				_, err := ComputeStats(config)
+				assert.Equal(GinkgoT(), 1, len(err))
+			})
+		})
+	})
+	Describe("Control drill-down", func() {
+		var (
+			config Config
+		)
+		BeforeEach(func() {
+			workingDir, _ := os.Getwd()
+			config = Config{
+				OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures"),
+				Certification:  "LATO",
+			}
+		})
+		Context("When the control is satisfied by a component", func() {
+			It("should find the control whatever its spelling along with the component details", func() {
+				details, err := FindControl(config, "NIST-800-53", "cm-02")
+				assert.Nil(GinkgoT(), err)
+				assert.Equal(GinkgoT(), "CM-2", details.Control)
+				assert.Equal(GinkgoT(), "Baseline Configuration", details.Name)
+				assert.Equal(GinkgoT(), "CM", details.Family)
+				assert.True(GinkgoT(), details.Required)
+				assert.Equal(GinkgoT(), 1, len(details.Components))
+				component := details.Components[0]
+				assert.Equal(GinkgoT(), "EC2", component.Key)
+				assert.Equal(GinkgoT(), []string{"partial", "planned"}, component.Statuses)
+				assert.Equal(GinkgoT(), []SectionDetails{
+					{Key: "a", Text: "Justification in narrative form A for CM-2"},
+					{Key: "b", Text: "Justification in narrative form B for CM-2"},
+				}, component.Narratives)
+				assert.Equal(GinkgoT(), []VerificationDetails{
+					{ComponentKey: "EC2", ComponentName: "Amazon Elastic Compute Cloud", VerificationKey: "EC2_Verification_1",
+						Name: "EC2 Verification 1", Path: "http://VerificationURL.com", Type: "URL", Found: true},
+					{ComponentKey: "UAA", VerificationKey: "UAA_Verification_1"},
+				}, component.CoveredBy)
+			})
+		})
+		Context("When the control is not required by the certification", func() {
+			It("should report it", func() {
+				details, err := FindControl(config, "PCI-DSS-MAY-2015", "3.1")
+				assert.Nil(GinkgoT(), err)
+				assert.False(GinkgoT(), details.Required)
+				assert.Equal(GinkgoT(), 0, len(details.Components))
+			})
+		})
+		Context("When the control cannot be found", func() {
+			It("should suggest the closest controls", func() {
+				_, err := FindControl(config, "PCI-DSS-MAY-2015", "31")
+				assert.Equal(GinkgoT(), []error{errors.New("Control 31 cannot be found in the standard PCI-DSS-MAY-2015. Did you mean 3.1, 1.1 or 2.1?")}, err)
+			})
+		})
+	})
 })
+
+func groupNames(groups []Group) []string {
+	var names []string
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	return names
+}
+
+func keys(i ComponentsInventory) []string {
+	var keys []string
+	for key := range i.SatisfiesMap {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,302 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package info
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/opencontrol/compliance-masonry/pkg/cli/clierrors"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/status"
+	"github.com/opencontrol/compliance-masonry/tools/constants"
+	"github.com/spf13/cobra"
+)
+
+// noneGroup is the group of the controls without a value for the breakdown, e.g. without a component.
+const noneGroup = "(none)"
+
+// statusOrder is the order in which the statuses are listed.
+var statusOrder = []string{"complete", "partial", "planned", "unsatisfied", "none", "not applicable", status.Unknown, status.Missing}
+
+// NewCmdStats reports statistics on the controls of a certification.
+func NewCmdStats(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats <certification>",
+		Short: "Get statistics on the implementation of the certification controls",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunStats(out, cmd, args)
+			clierrors.CheckError(err)
+		},
+	}
+	cmd.Flags().StringP("opencontrol", "o", constants.DefaultOpenControlsFolder, "Set opencontrol directory")
+	cmd.Flags().StringP("format", "f", "table", "Output format: table, json or csv")
+	cmd.Flags().String("status-policy", "", "YAML file of the policy that rolls up the statuses of the components")
+	return cmd
+}
+
+// RunStats runs stats when specified in cli
+func RunStats(out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("certification type not specified")
+	}
+
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments. expected only one certification type")
+	}
+	config := Config{
+		Certification:  args[0],
+		OpencontrolDir: cmd.Flag("opencontrol").Value.String(),
+		StatusPolicy:   cmd.Flag("status-policy").Value.String(),
+	}
+	format := cmd.Flag("format").Value.String()
+	if format != "table" && format != "json" && format != "csv" {
+		return fmt.Errorf("unsupported format '%s'. expected table, json or csv", format)
+	}
+	stats, errs := ComputeStats(config)
+	if errs != nil && len(errs) > 0 {
+		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), 1)
+	}
+	switch format {
+	case "json":
+		return stats.WriteJSON(out)
+	case "csv":
+		return stats.WriteCSV(out)
+	default:
+		return stats.WriteTable(out)
+	}
+}
+
+// Stats is the breakdown of the controls of a certification.
+type Stats struct {
+	Certification     string  `json:"certification"`
+	Controls          int     `json:"controls"`
+	ByStatus          []Group `json:"by_status"`
+	ByFamily          []Group `json:"by_family"`
+	ByControlOrigin   []Group `json:"by_control_origin"`
+	ByResponsibleRole []Group `json:"by_responsible_role"`
+	ByComponent       []Group `json:"by_component"`
+}
+
+// Group contains the number of certification controls that share a status, family, origin, role or component.
+// A control can belong to several groups of a breakdown, e.g. when it is satisfied by several components.
+type Group struct {
+	Name string `json:"name"`
+	// Controls is the number of certification controls in the group.
+	Controls int `json:"controls"`
+	// Percent is the percentage of the certification controls in the group.
+	Percent float64 `json:"percent"`
+	// Complete is the number of controls of the group with the effective status complete.
+	Complete int `json:"complete"`
+	// PercentComplete is the percentage of the controls of the group with the effective status complete.
+	PercentComplete float64 `json:"percent_complete"`
+}
+
+// breakdown counts the controls of the groups of a breakdown.
+type breakdown map[string]*Group
+
+func (b breakdown) add(names []string, complete bool) {
+	for _, name := range names {
+		group, found := b[name]
+		if !found {
+			group = &Group{Name: name}
+			b[name] = group
+		}
+		group.Controls++
+		if complete {
+			group.Complete++
+		}
+	}
+}
+
+// groups computes the percentages and sorts the groups with the given order, then in natural order.
+func (b breakdown) groups(total int, order []string) []Group {
+	rank := func(name string) int {
+		for idx, value := range order {
+			if value == name {
+				return idx
+			}
+		}
+		return len(order)
+	}
+	groups := make([]Group, 0, len(b))
+	for _, group := range b {
+		group.Percent = percent(group.Controls, total)
+		group.PercentComplete = percent(group.Complete, group.Controls)
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if rank(groups[i].Name) != rank(groups[j].Name) {
+			return rank(groups[i].Name) < rank(groups[j].Name)
+		}
+		return sortorder.NaturalLess(groups[i].Name, groups[j].Name)
+	})
+	return groups
+}
+
+func percent(count int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) * 100 / float64(total)
+}
+
+// ComputeStats breaks down the controls of the certification by effective status, family, control origin,
+// responsible role and component.
+func ComputeStats(config Config) (Stats, []error) {
+	i, errs := loadInventory(config)
+	if errs != nil {
+		return Stats{}, errs
+	}
+	stats := Stats{Certification: i.GetCertification().GetKey()}
+	byStatus, byFamily, byOrigin, byRole, byComponent := breakdown{}, breakdown{}, breakdown{}, breakdown{}, breakdown{}
+	for _, standardKey := range i.GetCertification().GetSortedStandards() {
+		var index *controlkeys.Index
+		if standard, found := i.GetStandard(standardKey); found {
+			standardIndex := controlkeys.NewIndex(standard)
+			index = &standardIndex
+		}
+		for _, controlKey := range i.GetCertification().GetControlKeysFor(standardKey) {
+			stats.Controls++
+			verifications := i.GetAllVerificationsWith(standardKey, controlKey)
+			effectiveStatus := i.GetEffectiveStatus(standardKey, controlKey)
+			complete := effectiveStatus == "complete"
+			byStatus.add([]string{effectiveStatus}, complete)
+
+			family := noneGroup
+			if index != nil {
+				if control, found := index.Get(controlKey); found && control.GetFamily() != "" {
+					family = control.GetFamily()
+				}
+			}
+			byFamily.add([]string{family}, complete)
+
+			origins, roles, components := make(map[string]bool), make(map[string]bool), make(map[string]bool)
+			for _, verification := range verifications {
+				components[verification.ComponentKey] = true
+				if component, found := i.GetComponent(verification.ComponentKey); found && component.GetResponsibleRole() != "" {
+					roles[component.GetResponsibleRole()] = true
+				}
+				for _, origin := range controlOrigins(verification.SatisfiesData) {
+					origins[origin] = true
+				}
+			}
+			byOrigin.add(names(origins), complete)
+			byRole.add(names(roles), complete)
+			byComponent.add(names(components), complete)
+		}
+	}
+	stats.ByStatus = byStatus.groups(stats.Controls, statusOrder)
+	stats.ByFamily = byFamily.groups(stats.Controls, nil)
+	stats.ByControlOrigin = byOrigin.groups(stats.Controls, nil)
+	stats.ByResponsibleRole = byRole.groups(stats.Controls, nil)
+	stats.ByComponent = byComponent.groups(stats.Controls, nil)
+	return stats, nil
+}
+
+// names returns the names of the set, or the none group if the set is empty.
+func names(set map[string]bool) []string {
+	if len(set) == 0 {
+		return []string{noneGroup}
+	}
+	var names []string
+	for name := range set {
+		names = append(names, name)
+	}
+	return names
+}
+
+// implementationStatuses returns all the statuses of a satisfies item.
+func implementationStatuses(satisfies common.Satisfies) []string {
+	if statuses := satisfies.GetImplementationStatuses(); len(statuses) > 0 {
+		return statuses
+	}
+	if status := satisfies.GetImplementationStatus(); status != "" {
+		return []string{status}
+	}
+	return nil
+}
+
+// controlOrigins returns all the control origins of a satisfies item.
+func controlOrigins(satisfies common.Satisfies) []string {
+	if origins := satisfies.GetControlOrigins(); len(origins) > 0 {
+		return origins
+	}
+	if origin := satisfies.GetControlOrigin(); origin != "" {
+		return []string{origin}
+	}
+	return nil
+}
+
+// WriteJSON writes the statistics as JSON.
+func (s Stats) WriteJSON(out io.Writer) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s\n", data)
+	return err
+}
+
+// breakdowns returns the breakdowns of the statistics along with their titles.
+func (s Stats) breakdowns() ([]string, [][]Group) {
+	return []string{"Status", "Family", "Control origin", "Responsible role", "Component"},
+		[][]Group{s.ByStatus, s.ByFamily, s.ByControlOrigin, s.ByResponsibleRole, s.ByComponent}
+}
+
+// WriteCSV writes the statistics as CSV with one row per group.
+func (s Stats) WriteCSV(out io.Writer) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"breakdown", "name", "controls", "percent", "complete", "percent_complete"}); err != nil {
+		return err
+	}
+	titles, breakdowns := s.breakdowns()
+	for idx, groups := range breakdowns {
+		for _, group := range groups {
+			err := w.Write([]string{titles[idx], group.Name, strconv.Itoa(group.Controls),
+				strconv.FormatFloat(group.Percent, 'f', 1, 64), strconv.Itoa(group.Complete),
+				strconv.FormatFloat(group.PercentComplete, 'f', 1, 64)})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteTable writes the statistics as text tables.
+func (s Stats) WriteTable(out io.Writer) error {
+	fmt.Fprintf(out, "# Certification %s: %d controls\n", s.Certification, s.Controls)
+	titles, breakdowns := s.breakdowns()
+	for idx, groups := range breakdowns {
+		fmt.Fprintln(out)
+		w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		if idx == 0 {
+			fmt.Fprintf(w, "%s\tControls\t%%\n", titles[idx])
+		} else {
+			fmt.Fprintf(w, "%s\tControls\t%%\tComplete\t%% complete\n", titles[idx])
+		}
+		for _, group := range groups {
+			if idx == 0 {
+				fmt.Fprintf(w, "%s\t%d\t%.1f%%\n", group.Name, group.Controls, group.Percent)
+			} else {
+				fmt.Fprintf(w, "%s\t%d\t%.1f%%\t%d\t%.1f%%\n", group.Name, group.Controls, group.Percent,
+					group.Complete, group.PercentComplete)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package matrix
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/opencontrol/compliance-masonry/pkg/cli/clierrors"
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/tools/constants"
+	"github.com/spf13/cobra"
+)
+
+// notSatisfied is the cell of a component that does not satisfy a control.
+const notSatisfied = "-"
+
+// NewCmdMatrix shows the statuses of the components for the controls of all the certifications.
+func NewCmdMatrix(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "matrix",
+		Short: "Get the control by component matrix of all the certifications",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunMatrix(out, cmd, args)
+			clierrors.CheckError(err)
+		},
+	}
+	cmd.Flags().StringP("opencontrol", "o", constants.DefaultOpenControlsFolder, "Set opencontrol directory")
+	cmd.Flags().StringP("format", "f", "table", "Output format: table, json or csv")
+	return cmd
+}
+
+// RunMatrix runs matrix when specified in cli
+func RunMatrix(out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("too many arguments. expected none")
+	}
+	config := Config{OpencontrolDir: cmd.Flag("opencontrol").Value.String()}
+	format := cmd.Flag("format").Value.String()
+	if format != "table" && format != "json" && format != "csv" {
+		return fmt.Errorf("unsupported format '%s'. expected table, json or csv", format)
+	}
+	matrix, errs := ComputeMatrix(config)
+	if errs != nil && len(errs) > 0 {
+		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), 1)
+	}
+	switch format {
+	case "json":
+		return matrix.WriteJSON(out)
+	case "csv":
+		return matrix.WriteCSV(out)
+	default:
+		return matrix.WriteTable(out)
+	}
+}
+
+// Config contains the settings for how to compute the matrix
+type Config struct {
+	OpencontrolDir string
+}
+
+// Matrix contains the statuses of the components for the controls of all the certifications.
+type Matrix struct {
+	Certifications []string `json:"certifications"`
+	// Components are the keys of all the components.
+	Components []string     `json:"components"`
+	Controls   []ControlRow `json:"controls"`
+	Coverage   []Coverage   `json:"coverage"`
+}
+
+// ControlRow contains the statuses of the components for a control required by at least one certification.
+type ControlRow struct {
+	Standard string `json:"standard"`
+	Control  string `json:"control"`
+	// Certifications are the certifications that require the control.
+	Certifications []string `json:"certifications"`
+	// Statuses are the implementation statuses of the components that satisfy the control, by component key.
+	Statuses        map[string][]string `json:"statuses"`
+	EffectiveStatus string              `json:"effective_status"`
+}
+
+// Coverage contains how many controls of a certification each component satisfies.
+type Coverage struct {
+	Certification string `json:"certification"`
+	Controls      int    `json:"controls"`
+	// Documented is the number of controls satisfied by at least one component.
+	Documented        int                 `json:"documented"`
+	PercentDocumented float64             `json:"percent_documented"`
+	Components        []ComponentCoverage `json:"components"`
+}
+
+// ComponentCoverage contains how many controls of a certification a component satisfies.
+type ComponentCoverage struct {
+	Component string  `json:"component"`
+	Controls  int     `json:"controls"`
+	Percent   float64 `json:"percent"`
+}
+
+// ComputeMatrix loads the components, the standards and all the certifications of the opencontrol directory
+// and computes the control by component matrix. Controls are matched by their normalized control key and
+// keep the spelling of the first certification that requires them.
+func ComputeMatrix(config Config) (Matrix, []error) {
+	workspace, errs := lib.LoadAllData(config.OpencontrolDir)
+	if len(errs) > 0 {
+		return Matrix{}, errs
+	}
+
+	matrix := Matrix{Components: make([]string, 0)}
+	for _, component := range workspace.GetAllComponents() {
+		matrix.Components = append(matrix.Components, component.GetKey())
+	}
+	sort.Sort(sortorder.Natural(matrix.Components))
+	for _, certification := range workspace.GetCertifications() {
+		matrix.Certifications = append(matrix.Certifications, certification.GetKey())
+	}
+
+	rows := make(map[string]*ControlRow)
+	for _, certification := range workspace.GetCertifications() {
+		name := certification.GetKey()
+		coverage := Coverage{Certification: name}
+		satisfiedBy := make(map[string]int)
+		seen := make(map[string]bool)
+		for _, standardKey := range certification.GetSortedStandards() {
+			for _, controlKey := range certification.GetControlKeysFor(standardKey) {
+				key := standardKey + "@" + controlkeys.Normalize(standardKey, controlKey)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				row, found := rows[key]
+				if !found {
+					row = &ControlRow{
+						Standard:        standardKey,
+						Control:         controlKey,
+						Statuses:        make(map[string][]string),
+						EffectiveStatus: workspace.GetEffectiveStatus(standardKey, controlKey),
+					}
+					for _, verification := range workspace.GetAllVerificationsWith(standardKey, controlKey) {
+						statuses := row.Statuses[verification.ComponentKey]
+						if statuses == nil {
+							statuses = make([]string, 0)
+						}
+						row.Statuses[verification.ComponentKey] = append(statuses, implementationStatuses(verification.SatisfiesData)...)
+					}
+					rows[key] = row
+				}
+				row.Certifications = append(row.Certifications, name)
+				coverage.Controls++
+				if len(row.Statuses) > 0 {
+					coverage.Documented++
+				}
+				for component := range row.Statuses {
+					satisfiedBy[component]++
+				}
+			}
+		}
+		coverage.PercentDocumented = percent(coverage.Documented, coverage.Controls)
+		for _, component := range matrix.Components {
+			coverage.Components = append(coverage.Components, ComponentCoverage{
+				Component: component,
+				Controls:  satisfiedBy[component],
+				Percent:   percent(satisfiedBy[component], coverage.Controls),
+			})
+		}
+		matrix.Coverage = append(matrix.Coverage, coverage)
+	}
+	for _, row := range rows {
+		matrix.Controls = append(matrix.Controls, *row)
+	}
+	sort.Slice(matrix.Controls, func(i, j int) bool {
+		if matrix.Controls[i].Standard != matrix.Controls[j].Standard {
+			return sortorder.NaturalLess(matrix.Controls[i].Standard, matrix.Controls[j].Standard)
+		}
+		return sortorder.NaturalLess(matrix.Controls[i].Control, matrix.Controls[j].Control)
+	})
+	return matrix, nil
+}
+
+// implementationStatuses returns all the statuses of a satisfies item.
+func implementationStatuses(satisfies common.Satisfies) []string {
+	if statuses := satisfies.GetImplementationStatuses(); len(statuses) > 0 {
+		return statuses
+	}
+	if status := satisfies.GetImplementationStatus(); status != "" {
+		return []string{status}
+	}
+	return nil
+}
+
+func percent(count int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) * 100 / float64(total)
+}
+
+// cell returns the statuses of a component for a control.
+func (r ControlRow) cell(component string) string {
+	statuses, found := r.Statuses[component]
+	if !found {
+		return notSatisfied
+	}
+	if len(statuses) == 0 {
+		return "unknown"
+	}
+	return strings.Join(statuses, ", ")
+}
+
+// WriteJSON writes the matrix as JSON.
+func (m Matrix) WriteJSON(out io.Writer) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s\n", data)
+	return err
+}
+
+// WriteCSV writes the control by component matrix as CSV. The coverage summary is not part of the CSV.
+func (m Matrix) WriteCSV(out io.Writer) error {
+	w := csv.NewWriter(out)
+	header := append([]string{"standard", "control", "certifications", "effective_status"}, m.Components...)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, row := range m.Controls {
+		record := []string{row.Standard, row.Control, strings.Join(row.Certifications, " "), row.EffectiveStatus}
+		for _, component := range m.Components {
+			record = append(record, row.cell(component))
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteTable writes the control by component matrix and the certification by component coverage as text tables.
+func (m Matrix) WriteTable(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Control\tCertifications\tEffective status\t%s\n", strings.Join(m.Components, "\t"))
+	for _, row := range m.Controls {
+		cells := make([]string, 0, len(m.Components))
+		for _, component := range m.Components {
+			cells = append(cells, row.cell(component))
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%s\t%s\n", row.Standard, row.Control, strings.Join(row.Certifications, ", "),
+			row.EffectiveStatus, strings.Join(cells, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Certification\tControls\tDocumented\t%s\n", strings.Join(m.Components, "\t"))
+	for _, coverage := range m.Coverage {
+		cells := make([]string, 0, len(coverage.Components))
+		for _, component := range coverage.Components {
+			cells = append(cells, fmt.Sprintf("%d (%.1f%%)", component.Controls, component.Percent))
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d (%.1f%%)\t%s\n", coverage.Certification, coverage.Controls, coverage.Documented,
+			coverage.PercentDocumented, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
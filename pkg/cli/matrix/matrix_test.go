@@ -0,0 +1,71 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package matrix_test
+
+import (
+	. "github.com/opencontrol/compliance-masonry/pkg/cli/matrix"
+
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("Matrix", func() {
+	var (
+		config Config
+	)
+	BeforeEach(func() {
+		workingDir, _ := os.Getwd()
+		config = Config{
+			OpencontrolDir: filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "opencontrol_fixtures"),
+		}
+	})
+	Context("When there are several certifications", func() {
+		It("should load all of them in one pass", func() {
+			matrix, err := ComputeMatrix(config)
+			assert.Nil(GinkgoT(), err)
+			assert.Equal(GinkgoT(), []string{"LATO", "LATO-base"}, matrix.Certifications)
+			assert.Equal(GinkgoT(), []string{"EC2"}, matrix.Components)
+		})
+		It("should match the controls of the certifications whatever their spelling", func() {
+			matrix, _ := ComputeMatrix(config)
+			assert.Equal(GinkgoT(), 6, len(matrix.Controls))
+			assert.Equal(GinkgoT(), ControlRow{
+				Standard: "NIST-800-53", Control: "AC-2", Certifications: []string{"LATO", "LATO-base"},
+				Statuses: map[string][]string{}, EffectiveStatus: "missing",
+			}, matrix.Controls[0])
+			assert.Equal(GinkgoT(), ControlRow{
+				Standard: "NIST-800-53", Control: "CM-2", Certifications: []string{"LATO"},
+				Statuses: map[string][]string{"EC2": {"partial", "planned"}}, EffectiveStatus: "partial",
+			}, matrix.Controls[2])
+		})
+		It("should summarize the coverage of each certification by component", func() {
+			matrix, _ := ComputeMatrix(config)
+			assert.Equal(GinkgoT(), []Coverage{
+				{Certification: "LATO", Controls: 6, Documented: 3, PercentDocumented: 50,
+					Components: []ComponentCoverage{{Component: "EC2", Controls: 3, Percent: 50}}},
+				{Certification: "LATO-base", Controls: 2, Documented: 1, PercentDocumented: 50,
+					Components: []ComponentCoverage{{Component: "EC2", Controls: 1, Percent: 50}}},
+			}, matrix.Coverage)
+		})
+		It("should write the matrix as CSV", func() {
+			matrix, _ := ComputeMatrix(config)
+			var out bytes.Buffer
+			assert.Nil(GinkgoT(), matrix.WriteCSV(&out))
+			assert.Contains(GinkgoT(), out.String(), "standard,control,certifications,effective_status,EC2\n"+
+				"NIST-800-53,AC-2,LATO LATO-base,missing,-\n")
+			assert.Contains(GinkgoT(), out.String(), "NIST-800-53,CM-2,LATO,partial,\"partial, planned\"\n")
+		})
+	})
+	Context("When there are no certifications", func() {
+		It("should return an error", func() {
+			config.OpencontrolDir = filepath.Join(config.OpencontrolDir, "components")
+			_, err := ComputeMatrix(config)
+			assert.NotEmpty(GinkgoT(), err)
+		})
+	})
+})
@@ -0,0 +1,394 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package migrate
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/blang/semver"
+	"github.com/opencontrol/compliance-masonry/pkg/cli/clierrors"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/components"
+	"github.com/opencontrol/compliance-masonry/tools/constants"
+	"github.com/opencontrol/compliance-masonry/tools/textdiff"
+	"github.com/opencontrol/compliance-masonry/tools/yamledit"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// NewCmdMigrate migrates the OpenControl files to newer formats.
+func NewCmdMigrate(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the OpenControl files to newer formats",
+	}
+	cmd.AddCommand(NewCmdMigrateComponents(out))
+	return cmd
+}
+
+// NewCmdMigrateComponents converts the component files to a newer schema version.
+func NewCmdMigrateComponents(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "components [component directories or files]",
+		Short: "Convert the component files to a newer schema version",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunMigrateComponents(out, cmd, args)
+			clierrors.CheckError(err)
+		},
+	}
+	cmd.Flags().StringP("opencontrol", "o", constants.DefaultOpenControlsFolder, "Set opencontrol directory")
+	cmd.Flags().String("to", components.ComponentV3_1_0.String(), "Schema version to convert the components to")
+	cmd.Flags().Bool("dry-run", false, "Print the changes without writing the component files")
+	return cmd
+}
+
+// RunMigrateComponents runs migrate components when specified in cli
+func RunMigrateComponents(out io.Writer, cmd *cobra.Command, args []string) error {
+	version, err := semver.Parse(cmd.Flag("to").Value.String())
+	if err != nil {
+		return common.ErrCantParseSemver
+	}
+	errs := MigrateComponents(out, ComponentsConfig{
+		OpencontrolDir: cmd.Flag("opencontrol").Value.String(),
+		Version:        version,
+		Components:     args,
+		DryRun:         cmd.Flag("dry-run").Value.String() == "true",
+	})
+	if len(errs) > 0 {
+		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), 1)
+	}
+	return nil
+}
+
+// ComponentsConfig contains the settings of a component schema migration.
+type ComponentsConfig struct {
+	OpencontrolDir string
+	Version        semver.Version
+	// Components are the component directories or files to migrate. The components of the opencontrol directory
+	// are migrated when there are none.
+	Components []string
+	// DryRun prints the changes without writing the component files.
+	DryRun bool
+}
+
+// MigrateComponents converts the component files to the schema version and prints the differences along with the
+// explanations of the values that cannot be carried over.
+func MigrateComponents(out io.Writer, config ComponentsConfig) []error {
+	fileNames, err := componentFiles(config.OpencontrolDir, config.Components)
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	for _, fileName := range fileNames {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		doc, explanations, err := migrateComponentSchema(data, fileName, config.Version)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Fprint(out, textdiff.Unified(fileName, fileName, textdiff.Lines(string(data)), doc.Lines()))
+		for _, explanation := range explanations {
+			fmt.Fprintf(out, "%s: %s\n", fileName, explanation)
+		}
+		if config.DryRun {
+			continue
+		}
+		if err := writeFile(fileName, doc.Bytes()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// migrateComponentSchema converts the component to the schema version and rewrites the fields of the document
+// that changed. The other fields keep their comments and formatting.
+func migrateComponentSchema(data []byte, fileName string, version semver.Version) (*yamledit.Document, []string, error) {
+	component, converted, explanations, err := components.ConvertComponent(data, fileName, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := yamledit.Parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	source, err := toMapSlice(component)
+	if err != nil {
+		return nil, nil, err
+	}
+	target, err := toMapSlice(converted)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The schema version is not a field of the component structs.
+	if err := setSchemaVersion(doc, version); err != nil {
+		return nil, nil, err
+	}
+	var sourceItems, targetItems []interface{}
+	for _, entry := range target {
+		if entry.Key == "satisfies" {
+			targetItems, _ = entry.Value.([]interface{})
+		}
+	}
+	for _, entry := range source {
+		if entry.Key == "satisfies" {
+			sourceItems, _ = entry.Value.([]interface{})
+		}
+	}
+	indented := sequenceStyle(doc)
+	topLevel := func() []*yamledit.Node { return doc.Root() }
+	unknown, err := patchMapping(doc, topLevel, without(source, "satisfies"), without(target, "satisfies"), indented)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, key := range unknown {
+		if key != "schema_version" && key != "satisfies" {
+			explanations = append(explanations, fmt.Sprintf("%s is not a field of the schema %s, it was left as is.",
+				key, version.String()))
+		}
+	}
+	if len(satisfiesItems(doc)) != len(targetItems) || len(sourceItems) != len(targetItems) {
+		return nil, nil, fmt.Errorf("Unable to migrate %s: the satisfies entries do not match the component", fileName)
+	}
+	for idx := range targetItems {
+		idx := idx
+		sourceItem, _ := sourceItems[idx].(yaml.MapSlice)
+		targetItem, _ := targetItems[idx].(yaml.MapSlice)
+		item := func() []*yamledit.Node { return satisfiesItems(doc)[idx].Children }
+		unknown, err := patchMapping(doc, item, sourceItem, targetItem, indented)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, key := range unknown {
+			explanations = append(explanations, fmt.Sprintf("Satisfy '%s': %s is not a field of the schema %s, it was left as is.",
+				converted.GetAllSatisfies()[idx].GetControlKey(), key, version.String()))
+		}
+	}
+	return doc, explanations, nil
+}
+
+// patchMapping rewrites the entries of a mapping of the document whose value changed from the source to the
+// target. Entries of the source that the target does not have are removed. The keys of the document that are
+// neither in the source nor in the target are returned.
+func patchMapping(doc *yamledit.Document, nodes func() []*yamledit.Node, source yaml.MapSlice, target yaml.MapSlice, indented bool) ([]string, error) {
+	var removed []string
+	for position, entry := range target {
+		key := fmt.Sprint(entry.Key)
+		value := prune(entry.Value)
+		sourceValue, found := lookup(source, key)
+		if found && reflect.DeepEqual(canonical(sourceValue), canonical(value)) {
+			// The document may have more than the struct, e.g. fields that are not parsed.
+			continue
+		}
+		node := find(nodes(), key)
+		switch {
+		case node == nil && isEmpty(value):
+		case node == nil:
+			if err := doc.InsertAfter(anchor(nodes(), target[:position]), render(key, value, indented)...); err != nil {
+				return nil, err
+			}
+		case isEmpty(value):
+			// Removing the entry afterwards lets the entries replacing it take its place.
+			removed = append(removed, key)
+		default:
+			if err := doc.Replace(node, render(key, value, indented)...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var unknown []string
+	for idx := 0; idx < len(nodes()); {
+		node := nodes()[idx]
+		if !contains(removed, node.Key) && (hasKey(target, node.Key) || !hasKey(source, node.Key)) {
+			if !hasKey(target, node.Key) {
+				unknown = append(unknown, node.Key)
+			}
+			idx++
+			continue
+		}
+		if err := doc.Remove(node); err != nil {
+			return nil, err
+		}
+	}
+	return unknown, nil
+}
+
+// setSchemaVersion sets the schema version of the document, or adds it before the first entry.
+func setSchemaVersion(doc *yamledit.Document, version semver.Version) error {
+	if schemaVersion := doc.Get("schema_version"); schemaVersion != nil {
+		if schemaVersion.Value == version.String() {
+			return nil
+		}
+		return doc.SetValue(schemaVersion, version.String())
+	}
+	first := doc.Root()[0]
+	return doc.InsertAt(first.Line, first.Column, "schema_version: "+version.String())
+}
+
+// toMapSlice returns the fields of a component in the order of its struct.
+func toMapSlice(component common.Component) (yaml.MapSlice, error) {
+	data, err := yaml.Marshal(component)
+	if err != nil {
+		return nil, err
+	}
+	var fields yaml.MapSlice
+	return fields, yaml.Unmarshal(data, &fields)
+}
+
+// render returns the lines of a mapping entry. Sequences are indented below their key when the document indents
+// them.
+func render(key string, value interface{}, indented bool) []string {
+	data, _ := yaml.Marshal(yaml.MapSlice{{Key: key, Value: value}})
+	lines := textdiff.Lines(string(data))
+	if _, isSequence := value.([]interface{}); isSequence && indented {
+		for idx := 1; idx < len(lines); idx++ {
+			lines[idx] = "  " + lines[idx]
+		}
+	}
+	return lines
+}
+
+// indentsSequences returns true when the first block sequence of the nodes is indented below its key.
+func indentsSequences(nodes []*yamledit.Node) (indented bool, found bool) {
+	for _, node := range nodes {
+		if !node.IsItem && len(node.Children) > 0 && node.Children[0].IsItem && node.Children[0].Line > node.Line {
+			return node.Children[0].Column > node.Column, true
+		}
+		if indented, found := indentsSequences(node.Children); found {
+			return indented, true
+		}
+	}
+	return false, false
+}
+
+// sequenceStyle returns true when the sequences of the document are indented below their key, following the first
+// sequence of the satisfies entries or else of the document.
+func sequenceStyle(doc *yamledit.Document) bool {
+	if indented, found := indentsSequences(satisfiesItems(doc)); found {
+		return indented
+	}
+	indented, _ := indentsSequences(doc.Root())
+	return indented
+}
+
+// prune removes the empty values of the mappings, as the component structs write all their fields.
+func prune(value interface{}) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		pruned := yaml.MapSlice{}
+		for _, entry := range v {
+			if entryValue := prune(entry.Value); !isEmpty(entryValue) {
+				pruned = append(pruned, yaml.MapItem{Key: entry.Key, Value: entryValue})
+			}
+		}
+		return pruned
+	case map[interface{}]interface{}:
+		pruned := map[interface{}]interface{}{}
+		for key, entryValue := range v {
+			if entryValue = prune(entryValue); !isEmpty(entryValue) {
+				pruned[key] = entryValue
+			}
+		}
+		return pruned
+	case []interface{}:
+		pruned := make([]interface{}, len(v))
+		for idx, item := range v {
+			pruned[idx] = prune(item)
+		}
+		return pruned
+	default:
+		return value
+	}
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case yaml.MapSlice:
+		return len(v) == 0
+	case map[interface{}]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// canonical returns a value that can be compared with reflect.DeepEqual whatever the order of its mappings.
+func canonical(value interface{}) interface{} {
+	data, err := yaml.Marshal(prune(value))
+	if err != nil {
+		return value
+	}
+	var result interface{}
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return value
+	}
+	return result
+}
+
+// anchor returns the node of the last previous entry that the document has, so that new entries are added in the
+// order of the struct. The last node is returned when there is none.
+func anchor(nodes []*yamledit.Node, previous yaml.MapSlice) *yamledit.Node {
+	for idx := len(previous) - 1; idx >= 0; idx-- {
+		if node := find(nodes, fmt.Sprint(previous[idx].Key)); node != nil {
+			return node
+		}
+	}
+	return nodes[len(nodes)-1]
+}
+
+func lookup(fields yaml.MapSlice, key string) (interface{}, bool) {
+	for _, entry := range fields {
+		if fmt.Sprint(entry.Key) == key {
+			return entry.Value, true
+		}
+	}
+	return nil, false
+}
+
+func find(nodes []*yamledit.Node, key string) *yamledit.Node {
+	for _, node := range nodes {
+		if !node.IsItem && node.Key == key {
+			return node
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, existing := range values {
+		if existing == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasKey(fields yaml.MapSlice, key string) bool {
+	_, found := lookup(fields, key)
+	return found
+}
+
+// without returns the fields without the given key.
+func without(fields yaml.MapSlice, key string) yaml.MapSlice {
+	var remaining yaml.MapSlice
+	for _, entry := range fields {
+		if fmt.Sprint(entry.Key) != key {
+			remaining = append(remaining, entry)
+		}
+	}
+	return remaining
+}
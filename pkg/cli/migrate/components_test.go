@@ -0,0 +1,68 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package migrate_test
+
+import (
+	. "github.com/opencontrol/compliance-masonry/pkg/cli/migrate"
+
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/components"
+	"github.com/opencontrol/compliance-masonry/tools/fs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("MigrateComponents", func() {
+	var (
+		fixturesDir string
+		dir         string
+		config      ComponentsConfig
+	)
+	BeforeEach(func() {
+		workingDir, _ := os.Getwd()
+		fixturesDir = filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "migrate_fixtures", "schema")
+		dir, _ = ioutil.TempDir("", "migrate")
+		assert.Nil(GinkgoT(), fs.CopyAll(fixturesDir, dir, ""))
+		config = ComponentsConfig{
+			Version:    components.ComponentV3_1_0,
+			Components: []string{filepath.Join(dir, "v3"), filepath.Join(dir, "v2")},
+		}
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+	Context("When converting the components to 3.1.0", func() {
+		It("should only rewrite the fields that changed and keep the comments", func() {
+			var out bytes.Buffer
+			errs := MigrateComponents(&out, config)
+			assert.Empty(GinkgoT(), errs)
+			for _, version := range []string{"v3", "v2"} {
+				expected, _ := ioutil.ReadFile(filepath.Join(fixturesDir, version, "component-3.1.0.yaml"))
+				migrated, _ := ioutil.ReadFile(filepath.Join(dir, version, "component.yaml"))
+				assert.Equal(GinkgoT(), string(expected), string(migrated), version)
+			}
+			// Converting again does not change the components.
+			out.Reset()
+			errs = MigrateComponents(&out, config)
+			assert.Empty(GinkgoT(), errs)
+			assert.NotContains(GinkgoT(), out.String(), "+++")
+		})
+		It("should explain the fields that cannot be carried over", func() {
+			var out bytes.Buffer
+			config.DryRun = true
+			MigrateComponents(&out, config)
+			assert.Contains(GinkgoT(), out.String(), filepath.Join(dir, "v3", "component.yaml")+
+				": documentation_complete is not a field of the schema 3.1.0, it was left as is.\n")
+			assert.Contains(GinkgoT(), out.String(), filepath.Join(dir, "v2", "component.yaml")+
+				": responsible_role is not set, the schema 2.0.0 has no responsible role.\n")
+			// The components are left unchanged.
+			original, _ := ioutil.ReadFile(filepath.Join(dir, "v2", "component.yaml"))
+			assert.Contains(GinkgoT(), string(original), "schema_version: 2.0\n")
+		})
+	})
+})
@@ -0,0 +1,447 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package migrate
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/opencontrol/compliance-masonry/pkg/cli/clierrors"
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/mappings"
+	"github.com/opencontrol/compliance-masonry/tools/constants"
+	"github.com/opencontrol/compliance-masonry/tools/textdiff"
+	"github.com/opencontrol/compliance-masonry/tools/yamledit"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdMigrateStandard moves the satisfies entries of the components from a standard to another one.
+func NewCmdMigrateStandard(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-standard [component directories or files]",
+		Short: "Move the satisfies entries of the components from a standard to another one using a mapping file",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunMigrateStandard(out, cmd, args)
+			clierrors.CheckError(err)
+		},
+	}
+	cmd.Flags().StringP("opencontrol", "o", constants.DefaultOpenControlsFolder, "Set opencontrol directory")
+	cmd.Flags().String("from", "", "Standard to migrate from, e.g. NIST-800-53")
+	cmd.Flags().String("to", "", "Standard to migrate to, e.g. NIST-800-53-rev5")
+	cmd.Flags().String("mapping", "", "Mapping file between the controls of the two standards")
+	cmd.Flags().String("report", "", "Write the review report to this file instead of the output")
+	cmd.Flags().Bool("dry-run", false, "Print the changes without writing the component files")
+	return cmd
+}
+
+// RunMigrateStandard runs migrate-standard when specified in cli
+func RunMigrateStandard(out io.Writer, cmd *cobra.Command, args []string) error {
+	config := StandardConfig{
+		OpencontrolDir: cmd.Flag("opencontrol").Value.String(),
+		From:           cmd.Flag("from").Value.String(),
+		To:             cmd.Flag("to").Value.String(),
+		Mapping:        cmd.Flag("mapping").Value.String(),
+		Components:     args,
+		DryRun:         cmd.Flag("dry-run").Value.String() == "true",
+	}
+	if config.From == "" || config.To == "" || config.Mapping == "" {
+		return fmt.Errorf("the --from, --to and --mapping flags are required")
+	}
+	report, errs := MigrateStandard(out, config)
+	if len(errs) > 0 {
+		return clierrors.NewExitError(clierrors.NewMultiError(errs...).Error(), 1)
+	}
+	reportFile := cmd.Flag("report").Value.String()
+	if reportFile == "" {
+		report.Write(out)
+		return nil
+	}
+	file, err := os.Create(reportFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	report.Write(file)
+	return nil
+}
+
+// StandardConfig contains the settings of a standard migration.
+type StandardConfig struct {
+	OpencontrolDir string
+	From           string
+	To             string
+	// Mapping is the path of the mapping file, in either direction between the two standards.
+	Mapping string
+	// Components are the component directories or files to migrate. The components of the opencontrol directory
+	// are migrated when there are none.
+	Components []string
+	// DryRun prints the changes without writing the component files.
+	DryRun bool
+}
+
+// StandardReport lists what a standard migration did that needs a human review.
+type StandardReport struct {
+	From       string
+	To         string
+	Components []ComponentReport
+	// Unmapped are the keys of the controls of the target standard that no mapping leads to.
+	Unmapped []string
+}
+
+// ComponentReport lists the changes of the satisfies entries of a component.
+type ComponentReport struct {
+	Key     string
+	File    string
+	Changes []string
+}
+
+// MigrateStandard rewrites the satisfies entries of the components using the mappings between the two standards,
+// prints the differences and returns the report of the migration.
+func MigrateStandard(out io.Writer, config StandardConfig) (StandardReport, []error) {
+	controlMappings, err := loadMappings(config.Mapping, config.From, config.To)
+	if err != nil {
+		return StandardReport{}, []error{err}
+	}
+	workspace := lib.NewWorkspace()
+	if errs := workspace.LoadStandards(filepath.Join(config.OpencontrolDir, constants.DefaultStandardsFolder)); len(errs) > 0 {
+		return StandardReport{}, errs
+	}
+	standard, found := workspace.GetStandard(config.To)
+	if !found {
+		return StandardReport{}, []error{fmt.Errorf("Standard %s cannot be found in the workspace", config.To)}
+	}
+	fileNames, err := componentFiles(config.OpencontrolDir, config.Components)
+	if err != nil {
+		return StandardReport{}, []error{err}
+	}
+	migration := standardMigration{from: config.From, to: config.To, mappings: controlMappings, index: controlkeys.NewIndex(standard)}
+	report := StandardReport{From: config.From, To: config.To, Unmapped: unmappedControls(standard, controlMappings)}
+	var errs []error
+	for _, fileName := range fileNames {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		doc, changes, err := migration.migrateComponent(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Unable to migrate %s: %v", fileName, err))
+			continue
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		report.Components = append(report.Components, ComponentReport{Key: componentKey(doc, fileName), File: fileName, Changes: changes})
+		fmt.Fprint(out, textdiff.Unified(fileName, fileName, textdiff.Lines(string(data)), doc.Lines()))
+		if config.DryRun {
+			continue
+		}
+		if err := writeFile(fileName, doc.Bytes()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return report, errs
+}
+
+// Write writes the report in Markdown.
+func (report StandardReport) Write(out io.Writer) {
+	fmt.Fprintf(out, "# Migration from %s to %s\n", report.From, report.To)
+	for _, component := range report.Components {
+		fmt.Fprintf(out, "\n## %s\n\n", component.Key)
+		fmt.Fprintf(out, "File: %s\n\n", component.File)
+		for _, change := range component.Changes {
+			fmt.Fprintf(out, "- %s\n", change)
+		}
+	}
+	fmt.Fprintf(out, "\n## Controls of %s without a mapping: %d\n\n", report.To, len(report.Unmapped))
+	for _, controlKey := range report.Unmapped {
+		fmt.Fprintf(out, "- %s\n", controlKey)
+	}
+}
+
+// standardMigration rewrites the satisfies entries of a component from a standard to another one.
+type standardMigration struct {
+	from     string
+	to       string
+	mappings []common.ControlMapping
+	// index finds the controls of the target standard.
+	index controlkeys.Index
+}
+
+// migrateComponent moves the satisfies entries of the source standard to the controls they are mapped to, and
+// returns the changes for the report. An entry mapped to several controls is copied for each of them. Entries
+// mapped to a control that is already satisfied are merged into it: their narratives are appended and their other
+// fields are dropped.
+func (m standardMigration) migrateComponent(data []byte) (*yamledit.Document, []string, error) {
+	doc, err := yamledit.Parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var changes []string
+	for idx := 0; idx < len(satisfiesItems(doc)); {
+		item := satisfiesItems(doc)[idx]
+		controlKey, standardKey := item.Get("control_key"), item.Get("standard_key")
+		if !controlKey.IsScalar() || !standardKey.IsScalar() || standardKey.Value != m.from {
+			idx++
+			continue
+		}
+		targets := m.targetsOf(controlKey.Value)
+		if len(targets) == 0 {
+			changes = append(changes, fmt.Sprintf("%s: no mapping, left on %s", controlKey.Value, m.from))
+			idx++
+			continue
+		}
+		source, text := controlKey.Value, doc.Text(item)
+		for _, mapping := range targets {
+			if _, found := m.index.Find(mapping.TargetControl); !found {
+				changes = append(changes, fmt.Sprintf("%s: mapped to %s, which cannot be found in %s", source,
+					mapping.TargetControl, m.to))
+			}
+		}
+		// The other targets are handled first, so that the entry is still at idx.
+		var others []string
+		for position := len(targets) - 1; position > 0; position-- {
+			mapping := targets[position]
+			if existing := m.findSatisfied(doc, mapping.TargetControl); existing >= 0 {
+				dropped, err := m.merge(doc, existing, text, source)
+				if err != nil {
+					return nil, nil, err
+				}
+				others = append([]string{fmt.Sprintf("%s: merged into %s%s%s", source, mapping.TargetControl,
+					relationshipNote(mapping), droppedNote(dropped))}, others...)
+				continue
+			}
+			if err := doc.InsertAfter(satisfiesItems(doc)[idx], textdiff.Lines(text)...); err != nil {
+				return nil, nil, err
+			}
+			if err := m.setKeys(doc, idx+1, mapping.TargetControl); err != nil {
+				return nil, nil, err
+			}
+			others = append([]string{fmt.Sprintf("%s: copied to %s%s", source, mapping.TargetControl, relationshipNote(mapping))}, others...)
+		}
+		mapping, removed := targets[0], false
+		if existing := m.findSatisfied(doc, mapping.TargetControl); existing >= 0 {
+			dropped, err := m.merge(doc, existing, text, source)
+			if err != nil {
+				return nil, nil, err
+			}
+			// Adding lines to another entry does not move the entry.
+			if err := doc.Remove(satisfiesItems(doc)[idx]); err != nil {
+				return nil, nil, err
+			}
+			removed = true
+			changes = append(changes, fmt.Sprintf("%s: merged into %s%s%s", source, mapping.TargetControl,
+				relationshipNote(mapping), droppedNote(dropped)))
+		} else {
+			if err := m.setKeys(doc, idx, mapping.TargetControl); err != nil {
+				return nil, nil, err
+			}
+			changes = append(changes, fmt.Sprintf("%s: moved to %s%s", source, mapping.TargetControl, relationshipNote(mapping)))
+		}
+		changes = append(changes, others...)
+		if len(targets) > 1 {
+			changes = append(changes, fmt.Sprintf("%s: split into %d controls, review the narrative of each of them",
+				source, len(targets)))
+		}
+		// The copies that follow are entries of the target standard, they are skipped.
+		if !removed {
+			idx++
+		}
+	}
+	return doc, changes, nil
+}
+
+// targetsOf returns the mappings of a control of the source standard.
+func (m standardMigration) targetsOf(controlKey string) []common.ControlMapping {
+	var targets []common.ControlMapping
+	for _, mapping := range m.mappings {
+		if controlkeys.Equal(m.from, mapping.SourceControl, controlKey) {
+			targets = append(targets, mapping)
+		}
+	}
+	return targets
+}
+
+// findSatisfied returns the index of the satisfies entry of a control of the target standard, or -1.
+func (m standardMigration) findSatisfied(doc *yamledit.Document, controlKey string) int {
+	for idx, item := range satisfiesItems(doc) {
+		if item.Get("standard_key").IsScalar() && item.Get("standard_key").Value == m.to &&
+			item.Get("control_key").IsScalar() && controlkeys.Equal(m.to, item.Get("control_key").Value, controlKey) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// setKeys moves the satisfies entry at the given index to a control of the target standard.
+func (m standardMigration) setKeys(doc *yamledit.Document, idx int, controlKey string) error {
+	if err := doc.SetValue(satisfiesItems(doc)[idx].Get("control_key"), controlKey); err != nil {
+		return err
+	}
+	return doc.SetValue(satisfiesItems(doc)[idx].Get("standard_key"), m.to)
+}
+
+// merge appends the narratives of a satisfies entry, given as the text of its item, to the narratives of the entry
+// at the target index and returns the fields of the merged entry that differ and are dropped.
+func (m standardMigration) merge(doc *yamledit.Document, target int, text string, source string) ([]string, error) {
+	sourceDoc, err := yamledit.Parse([]byte(text))
+	if err != nil {
+		return nil, err
+	}
+	sourceItem := sourceDoc.Root()[0]
+	var sourceContent, targetContent map[string]interface{}
+	if err := sourceDoc.Unmarshal(sourceItem, &sourceContent); err != nil {
+		return nil, err
+	}
+	if err := doc.Unmarshal(satisfiesItems(doc)[target], &targetContent); err != nil {
+		return nil, err
+	}
+	var dropped []string
+	for key, value := range sourceContent {
+		switch key {
+		case "control_key", "standard_key", "narrative":
+		default:
+			if !reflect.DeepEqual(value, targetContent[key]) {
+				dropped = append(dropped, key)
+			}
+		}
+	}
+	sort.Strings(dropped)
+	narrative := sourceItem.Get("narrative")
+	if narrative == nil || len(narrative.Children) == 0 {
+		return dropped, nil
+	}
+	lines := []string{fmt.Sprintf("# Merged from %s %s", m.from, source)}
+	for _, child := range narrative.Children {
+		lines = append(lines, textdiff.Lines(sourceDoc.Text(child))...)
+	}
+	targetItem := satisfiesItems(doc)[target]
+	targetNarrative := targetItem.Get("narrative")
+	switch {
+	case targetNarrative == nil:
+		last := targetItem.Children[len(targetItem.Children)-1]
+		indented := []string{"narrative:"}
+		for _, line := range lines {
+			indented = append(indented, "  "+line)
+		}
+		return dropped, doc.InsertAfter(last, indented...)
+	case len(targetNarrative.Children) == 0:
+		return append(dropped, "narrative"), nil
+	default:
+		return dropped, doc.InsertAfter(targetNarrative.Children[len(targetNarrative.Children)-1], lines...)
+	}
+}
+
+// loadMappings loads the mappings from the source to the target standard. A mapping file in the other direction
+// is reversed.
+func loadMappings(path string, from string, to string) ([]common.ControlMapping, error) {
+	controlMappings, err := mappings.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	var result []common.ControlMapping
+	for _, mapping := range controlMappings {
+		switch {
+		case mapping.SourceStandard == from && mapping.TargetStandard == to:
+			result = append(result, mapping)
+		case mapping.SourceStandard == to && mapping.TargetStandard == from:
+			result = append(result, mapping.Reverse())
+		default:
+			return nil, fmt.Errorf("The mappings %s are between %s and %s, not %s and %s", path,
+				mapping.SourceStandard, mapping.TargetStandard, from, to)
+		}
+	}
+	return result, nil
+}
+
+// unmappedControls returns the keys of the controls of the standard that no mapping leads to. Withdrawn controls
+// are skipped.
+func unmappedControls(standard common.Standard, controlMappings []common.ControlMapping) []string {
+	mapped := make(map[string]bool)
+	for _, mapping := range controlMappings {
+		mapped[controlkeys.Normalize(mapping.TargetStandard, mapping.TargetControl)] = true
+	}
+	var unmapped []string
+	for _, controlKey := range standard.GetSortedControls() {
+		if !mapped[controlkeys.Normalize(standard.GetName(), controlKey)] && !standard.GetControl(controlKey).IsWithdrawn() {
+			unmapped = append(unmapped, controlKey)
+		}
+	}
+	return unmapped
+}
+
+// relationshipNote explains what to review when the controls do not have the same requirements.
+func relationshipNote(mapping common.ControlMapping) string {
+	switch mapping.Relationship {
+	case common.RelationshipSubset:
+		return fmt.Sprintf(" (subset: check that the narrative covers all the requirements of %s)", mapping.TargetControl)
+	case common.RelationshipIntersects:
+		return fmt.Sprintf(" (intersects: check the narrative against the requirements of %s)", mapping.TargetControl)
+	default:
+		return ""
+	}
+}
+
+func droppedNote(dropped []string) string {
+	if len(dropped) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", review the dropped %s", strings.Join(dropped, ", "))
+}
+
+// satisfiesItems returns the items of the satisfies sequence of the document.
+func satisfiesItems(doc *yamledit.Document) []*yamledit.Node {
+	satisfies := doc.Get("satisfies")
+	if satisfies == nil {
+		return nil
+	}
+	return satisfies.Children
+}
+
+// componentFiles returns the component files of the arguments, or the ones of the opencontrol directory.
+func componentFiles(opencontrolDir string, components []string) ([]string, error) {
+	if len(components) == 0 {
+		fileNames, err := filepath.Glob(filepath.Join(opencontrolDir, constants.DefaultComponentsFolder, "*", "component.yaml"))
+		sort.Strings(fileNames)
+		return fileNames, err
+	}
+	var fileNames []string
+	for _, component := range components {
+		info, err := os.Stat(component)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			component = filepath.Join(component, "component.yaml")
+		}
+		fileNames = append(fileNames, component)
+	}
+	return fileNames, nil
+}
+
+// componentKey returns the key of the component, or the name of its directory.
+func componentKey(doc *yamledit.Document, fileName string) string {
+	if key := doc.Get("key"); key.IsScalar() {
+		return key.Value
+	}
+	return filepath.Base(filepath.Dir(fileName))
+}
+
+// writeFile replaces the content of a file, keeping its mode.
+func writeFile(fileName string, data []byte) error {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, info.Mode())
+}
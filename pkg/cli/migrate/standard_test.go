@@ -0,0 +1,94 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package migrate_test
+
+import (
+	. "github.com/opencontrol/compliance-masonry/pkg/cli/migrate"
+
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	"github.com/opencontrol/compliance-masonry/tools/fs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("MigrateStandard", func() {
+	var (
+		fixturesDir string
+		config      StandardConfig
+	)
+	BeforeEach(func() {
+		workingDir, _ := os.Getwd()
+		fixturesDir = filepath.Join(workingDir, "..", "..", "..", "test", "fixtures", "migrate_fixtures")
+		config = StandardConfig{
+			OpencontrolDir: fixturesDir,
+			From:           "NIST-800-53",
+			To:             "NIST-800-53-rev5",
+			Mapping:        filepath.Join(fixturesDir, "mappings", "NIST-800-53-to-NIST-800-53-rev5.yaml"),
+			DryRun:         true,
+		}
+	})
+	Context("When migrating the components", func() {
+		It("should rewrite the satisfies entries and keep the comments", func() {
+			dir, err := ioutil.TempDir("", "migrate")
+			assert.Nil(GinkgoT(), err)
+			defer os.RemoveAll(dir)
+			assert.Nil(GinkgoT(), fs.CopyAll(fixturesDir, dir, ""))
+			config.OpencontrolDir, config.DryRun = dir, false
+			var out bytes.Buffer
+			_, errs := MigrateStandard(&out, config)
+			assert.Empty(GinkgoT(), errs)
+			expected, _ := ioutil.ReadFile(filepath.Join(fixturesDir, "component-migrated.yaml"))
+			migrated, _ := ioutil.ReadFile(filepath.Join(dir, "components", "Web", "component.yaml"))
+			assert.Equal(GinkgoT(), string(expected), string(migrated))
+			// Migrating again only reports the controls without a mapping.
+			report, errs := MigrateStandard(&out, config)
+			assert.Empty(GinkgoT(), errs)
+			assert.Equal(GinkgoT(), []string{"PE-1: no mapping, left on NIST-800-53"}, report.Components[0].Changes)
+		})
+		It("should report the changes to review", func() {
+			var out bytes.Buffer
+			report, errs := MigrateStandard(&out, config)
+			assert.Empty(GinkgoT(), errs)
+			assert.Equal(GinkgoT(), 1, len(report.Components))
+			assert.Equal(GinkgoT(), "Web", report.Components[0].Key)
+			assert.Equal(GinkgoT(), []string{
+				"AC-2: moved to AC-2",
+				"AC-02 (10): merged into AC-2 (subset: check that the narrative covers all the requirements of AC-2)",
+				"CM-2: moved to CM-2",
+				"CM-2(1): merged into CM-2 (subset: check that the narrative covers all the requirements of CM-2), review the dropped implementation_statuses",
+				"SA-12: moved to SR-1 (intersects: check the narrative against the requirements of SR-1)",
+				"SA-12: copied to SR-2 (intersects: check the narrative against the requirements of SR-2)",
+				"SA-12: split into 2 controls, review the narrative of each of them",
+				"PE-1: no mapping, left on NIST-800-53",
+			}, report.Components[0].Changes)
+			assert.Equal(GinkgoT(), []string{"AC-2 (13)"}, report.Unmapped)
+			// The component is left unchanged.
+			original, _ := ioutil.ReadFile(filepath.Join(fixturesDir, "components", "Web", "component.yaml"))
+			assert.Contains(GinkgoT(), string(original), "- control_key: SA-12")
+			assert.Contains(GinkgoT(), out.String(), "+- control_key: SR-2")
+			out.Reset()
+			report.Write(&out)
+			assert.Contains(GinkgoT(), out.String(), "## Controls of NIST-800-53-rev5 without a mapping: 1\n\n- AC-2 (13)\n")
+		})
+		It("should accept a mapping file in the other direction", func() {
+			config.From, config.To = "NIST-800-53-rev5", "NIST-800-53"
+			_, errs := MigrateStandard(&bytes.Buffer{}, config)
+			assert.Equal(GinkgoT(), 1, len(errs))
+			assert.EqualError(GinkgoT(), errs[0], "Standard NIST-800-53 cannot be found in the workspace")
+		})
+	})
+	Context("When the mapping file is between other standards", func() {
+		It("should return an error", func() {
+			config.From = "ISO-27001"
+			_, errs := MigrateStandard(&bytes.Buffer{}, config)
+			assert.Equal(GinkgoT(), 1, len(errs))
+			assert.EqualError(GinkgoT(), errs[0], "The mappings "+config.Mapping+
+				" are between NIST-800-53 and NIST-800-53-rev5, not ISO-27001 and NIST-800-53-rev5")
+		})
+	})
+})
@@ -7,14 +7,37 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// fix boolean flag
+var fixFlag bool
+
+// lint rules flag
+var lintFlag []string
+
+// placeholder patterns flag
+var placeholderFlag []string
+
+// minimum narrative words flag
+var minWordsFlag int
+
 // NewCmdValidate validates the current masonry
 func NewCmdValidate(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validates the current opencontrol masonry repository. Use get command to create opencontrol masonry repository.",
 		Run: func(cmd *cobra.Command, args []string) {
-			validate.Validate()
+			validate.Validate(out, validate.Config{
+				Fix: fixFlag,
+				Lint: validate.LintConfig{
+					Rules:        lintFlag,
+					Placeholders: placeholderFlag,
+					MinWords:     minWordsFlag,
+				},
+			})
 		},
 	}
+	cmd.Flags().BoolVar(&fixFlag, "fix", false, "Rewrite the component files to fix the problems that do not need a human decision")
+	cmd.Flags().StringSliceVar(&lintFlag, "lint", nil, "Narrative lint rules to enable: placeholder, min-length, duplicate, copied, empty-complete or all")
+	cmd.Flags().StringSliceVar(&placeholderFlag, "placeholder", validate.DefaultPlaceholders, "Regular expressions matching placeholder narratives")
+	cmd.Flags().IntVar(&minWordsFlag, "min-words", validate.DefaultMinWords, "Minimum number of words of a narrative")
 	return cmd
 }
@@ -10,12 +10,16 @@ import (
 	"log"
 	"os"
 
+	"github.com/opencontrol/compliance-masonry/pkg/cli/changes"
 	"github.com/opencontrol/compliance-masonry/pkg/cli/clierrors"
+	"github.com/opencontrol/compliance-masonry/pkg/cli/crosswalk"
 	"github.com/opencontrol/compliance-masonry/pkg/cli/diff"
 	"github.com/opencontrol/compliance-masonry/pkg/cli/docs"
 	"github.com/opencontrol/compliance-masonry/pkg/cli/export"
 	"github.com/opencontrol/compliance-masonry/pkg/cli/get"
 	"github.com/opencontrol/compliance-masonry/pkg/cli/info"
+	"github.com/opencontrol/compliance-masonry/pkg/cli/matrix"
+	"github.com/opencontrol/compliance-masonry/pkg/cli/migrate"
 	"github.com/opencontrol/compliance-masonry/pkg/cli/validate"
 	cliversion "github.com/opencontrol/compliance-masonry/pkg/cli/version"
 	"github.com/opencontrol/compliance-masonry/version"
@@ -58,8 +62,13 @@ the OpenControl Schema`,
 	cmds.PersistentFlags().BoolVarP(&Version, "version", "v", false, "Print the version")
 
 	// Add new main commands here
+	cmds.AddCommand(changes.NewCmdChanges(out))
+	cmds.AddCommand(crosswalk.NewCmdCrosswalk(out))
 	cmds.AddCommand(diff.NewCmdDiff(out))
 	cmds.AddCommand(info.NewCmdInfo(out))
+	cmds.AddCommand(matrix.NewCmdMatrix(out))
+	cmds.AddCommand(migrate.NewCmdMigrate(out))
+	cmds.AddCommand(migrate.NewCmdMigrateStandard(out))
 	cmds.AddCommand(docs.NewCmdDocs(out))
 	cmds.AddCommand(export.NewCmdExport(out))
 	cmds.AddCommand(get.NewCmdGet(out))
@@ -5,21 +5,109 @@
 package lib
 
 import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fvbommel/sortorder"
+
 	"github.com/opencontrol/compliance-masonry/pkg/lib/certifications"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/tools/suggest"
 )
 
 // LoadCertification struct loads certifications into a Certification struct
-// and add it to the main object.
+// and add it to the main object. It is the certification returned by GetCertification.
 func (ws *localWorkspace) LoadCertification(certificationFile string) error {
 	cert, err := certifications.Load(certificationFile)
 	if err != nil {
 		return err
 	}
 	ws.certification = cert
+	ws.certifications[certificationKey(certificationFile)] = cert
 	return nil
 }
 
 func (ws *localWorkspace) GetCertification() common.Certification {
 	return ws.certification
 }
+
+// LoadCertifications loads all the certifications of a directory into the workspace. They are keyed by their file
+// name without extension, which is how they are named on the command line.
+func (ws *localWorkspace) LoadCertifications(certificationsDir string) []error {
+	files, err := filepath.Glob(filepath.Join(certificationsDir, "*.yaml"))
+	if err != nil {
+		return []error{err}
+	}
+	if len(files) == 0 {
+		return []error{fmt.Errorf("Error: No certifications found in `%s`", certificationsDir)}
+	}
+	var errs []error
+	for _, file := range files {
+		cert, err := certifications.Load(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Unable to load the certification %s: %v", file, err))
+			continue
+		}
+		ws.certifications[certificationKey(file)] = cert
+	}
+	return errs
+}
+
+// GetCertifications returns all the certifications loaded into the workspace, sorted by key.
+func (ws *localWorkspace) GetCertifications() []common.Certification {
+	var keys []string
+	for key := range ws.certifications {
+		keys = append(keys, key)
+	}
+	sort.Sort(sortorder.Natural(keys))
+	var certs []common.Certification
+	for _, key := range keys {
+		certs = append(certs, ws.certifications[key])
+	}
+	return certs
+}
+
+// GetCertificationByKey returns a certification loaded into the workspace by its file name without extension.
+func (ws *localWorkspace) GetCertificationByKey(key string) (common.Certification, bool) {
+	cert, found := ws.certifications[key]
+	return cert, found
+}
+
+// certificationKey returns the file name of a certification without extension.
+func certificationKey(certificationFile string) string {
+	return strings.TrimSuffix(filepath.Base(certificationFile), filepath.Ext(certificationFile))
+}
+
+// CheckCertification verifies that every standard and control listed in the certification of the workspace
+// can be found in the standards loaded into the workspace.
+// An error is returned for each standard that is missing and for each control key that is missing from its standard.
+// The errors suggest the closest standard or control keys when there are some.
+func CheckCertification(ws common.Workspace) []error {
+	var errs []error
+	certification := ws.GetCertification()
+	if certification == nil {
+		return errs
+	}
+	for _, standardKey := range certification.GetSortedStandards() {
+		standard, found := ws.GetStandard(standardKey)
+		if !found {
+			message := fmt.Sprintf("Certification %s references standard %s, however that cannot be found in the workspace.",
+				certification.GetKey(), standardKey)
+			errs = append(errs, errors.New(suggest.Append(message, standardKey, GetStandardKeys(ws))))
+			continue
+		}
+		controls := controlkeys.NewIndex(standard)
+		for _, controlKey := range certification.GetControlKeysFor(standardKey) {
+			if _, found := controls.Find(controlKey); !found {
+				message := fmt.Sprintf("Certification %s references control %s, however that cannot be found in the standard %s.",
+					certification.GetKey(), controlKey, standardKey)
+				errs = append(errs, errors.New(suggest.Append(message, controlKey, standard.GetSortedControls())))
+			}
+		}
+	}
+	return errs
+}
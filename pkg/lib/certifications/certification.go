@@ -7,24 +7,75 @@ package certifications
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/blang/semver"
 	v1_0_0 "github.com/opencontrol/compliance-masonry/pkg/lib/certifications/versions/1_0_0"
+	v2_0_0 "github.com/opencontrol/compliance-masonry/pkg/lib/certifications/versions/2_0_0"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
 	"gopkg.in/yaml.v2"
-	"io/ioutil"
 )
 
 // Load will read the file at the given path and attempt to return a Certification object.
+// A certification that extends another certification is resolved with the certification of the same directory
+// named after the key it extends.
 func Load(certificationFile string) (common.Certification, error) {
-	// Only one version right now and there's no schema version right now to indicate which version.
-	var certification v1_0_0.Certification
+	return load(certificationFile, nil)
+}
+
+// load loads a certification given the chain of certification keys that extend it, to detect cycles.
+func load(certificationFile string, chain []string) (common.Certification, error) {
 	certificationData, err := ioutil.ReadFile(certificationFile)
 	if err != nil {
 		return nil, common.ErrReadFile
 	}
-	err = yaml.Unmarshal(certificationData, &certification)
-	if err != nil {
+	var base struct {
+		SchemaVersion string `yaml:"schema_version"`
+	}
+	if err := yaml.Unmarshal(certificationData, &base); err != nil {
 		return nil, common.ErrCertificationSchema
 	}
+	// Certifications without a schema version are of the version 1.0.0.
+	version := semver.Version{Major: 1}
+	if base.SchemaVersion != "" {
+		if version, err = semver.Parse(base.SchemaVersion); err != nil || version.Major > 2 {
+			return nil, fmt.Errorf("unsupported certification schema version %s in %s", base.SchemaVersion, certificationFile)
+		}
+	}
+	if version.Major < 2 {
+		var certification v1_0_0.Certification
+		if err := yaml.Unmarshal(certificationData, &certification); err != nil {
+			return nil, common.ErrCertificationSchema
+		}
+		return certification, nil
+	}
+	var certification v2_0_0.Certification
+	if err := yaml.UnmarshalStrict(certificationData, &certification); err != nil {
+		return nil, common.ErrCertificationSchema
+	}
+	var extended common.Certification
+	if certification.Extends != "" {
+		key := strings.TrimSuffix(filepath.Base(certificationFile), filepath.Ext(certificationFile))
+		chain = append(chain, key)
+		for _, extendingKey := range chain {
+			if extendingKey == certification.Extends {
+				return nil, fmt.Errorf("certification cycle: %s -> %s", strings.Join(chain, " -> "), certification.Extends)
+			}
+		}
+		extendedFile := filepath.Join(filepath.Dir(certificationFile), certification.Extends+filepath.Ext(certificationFile))
+		if extended, err = load(extendedFile, chain); err == common.ErrReadFile {
+			return nil, fmt.Errorf("certification %s extends %s, however %s cannot be read", key, certification.Extends,
+				extendedFile)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	if err := certification.Resolve(extended); err != nil {
+		return nil, err
+	}
 	return certification, nil
 }
 
@@ -36,8 +87,11 @@ func MarshalJSON(certification common.Certification) (b []byte, e error) {
 		err                error
 	)
 	vCertification1_0_0, ok := certification.(v1_0_0.Certification)
+	vCertification2_0_0, ok2 := certification.(v2_0_0.Certification)
 	if ok {
 		bytesCertification, err = json.Marshal(&vCertification1_0_0)
+	} else if ok2 {
+		bytesCertification, err = json.Marshal(&vCertification2_0_0)
 	} else {
 		return nil, errors.New("unsupported certification version")
 	}
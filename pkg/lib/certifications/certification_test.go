@@ -68,3 +68,76 @@ func TestLoadCertificationErrors(t *testing.T) {
 		}
 	}
 }
+
+type tailoredCertificationTest struct {
+	certificationFile string
+	expectedControls  map[string][]string
+	expectedSources   map[string]string
+}
+
+var tailoredCertificationTests = []tailoredCertificationTest{
+	// Test a certification that extends another one, adds controls and removes some, whatever their spelling
+	{
+		filepath.Join("..", "..", "..", "test", "fixtures", "certification_fixtures", "internal.yaml"),
+		map[string][]string{
+			"NIST-800-53": {"AC-2", "AC-6", "CM-2"},
+		},
+		map[string]string{"AC-2": "moderate", "AC-6": "moderate", "CM-2": "internal"},
+	},
+	// Test a chain of certifications
+	{
+		filepath.Join("..", "..", "..", "test", "fixtures", "certification_fixtures", "internal-high.yaml"),
+		map[string][]string{
+			"NIST-800-53": {"AC-2", "AU-2", "CM-2"},
+		},
+		map[string]string{"AC-2": "moderate", "AU-2": "internal-high", "CM-2": "internal"},
+	},
+}
+
+func TestLoadTailoredCertification(t *testing.T) {
+	for _, example := range tailoredCertificationTests {
+		actual, err := certifications.Load(example.certificationFile)
+		assert.Nil(t, err)
+		// Check that the standards without controls are dropped
+		assert.Equal(t, []string{"NIST-800-53"}, actual.GetSortedStandards())
+		for expectedStandardKey, expectedControls := range example.expectedControls {
+			assert.Equal(t, expectedControls, actual.GetControlKeysFor(expectedStandardKey))
+			for _, controlKey := range expectedControls {
+				assert.Equal(t, example.expectedSources[controlKey], actual.GetControlSource(expectedStandardKey, controlKey))
+			}
+		}
+	}
+}
+
+var tailoredCertificationTestErrors = []struct {
+	certificationFile string
+	expectedError     string
+}{
+	// Test a cycle of certifications
+	{"cycle-a.yaml", "certification cycle: cycle-a -> cycle-b -> cycle-a"},
+	// Test a removed control that is not in the extended certification
+	{"remove-unknown.yaml", "certification remove-unknown removes control AU-2 of standard NIST-800-53, however it is not in the certification"},
+	// Test a certification that extends a certification that does not exist
+	{"extends-missing.yaml", "certification extends-missing extends low, however " +
+		filepath.Join("..", "..", "..", "test", "fixtures", "certification_fixtures", "low.yaml") + " cannot be read"},
+}
+
+func TestLoadTailoredCertificationErrors(t *testing.T) {
+	for _, example := range tailoredCertificationTestErrors {
+		_, err := certifications.Load(filepath.Join("..", "..", "..", "test", "fixtures", "certification_fixtures", example.certificationFile))
+		assert.EqualError(t, err, example.expectedError)
+	}
+}
+
+func TestLoadCertificationRequirements(t *testing.T) {
+	actual, err := certifications.Load(filepath.Join("..", "..", "..", "test", "fixtures", "certification_fixtures", "LATO-guidance.yaml"))
+	assert.Nil(t, err)
+	// Check that the data of a control is loaded and found whatever the spelling of the control key
+	assert.Equal(t, common.CertificationControl{
+		Guidance:   "The service provider reviews the baseline configuration at least annually.",
+		Parameters: map[string]common.ParameterAssignment{"b (1)": {Value: "at least annually"}},
+		Notes:      "Applies to all production hosts.",
+	}, actual.GetControlRequirements("NIST-800-53", "CM-02"))
+	// Check that controls without data have empty data
+	assert.True(t, actual.GetControlRequirements("NIST-800-53", "AC-2").IsEmpty())
+}
@@ -5,36 +5,47 @@
 package certification
 
 import (
-	"bytes"
 	"encoding/json"
-	"fmt"
 	"github.com/fvbommel/sortorder"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
 	"sort"
 )
 
 // Certification struct is a collection of specific standards and controls
 // Schema info: https://github.com/opencontrol/schemas#certifications
 type Certification struct {
-	Key       string                            `yaml:"name" json:"name"`
-	Standards map[string]map[string]interface{} `yaml:"standards" json:"standards"`
+	Key       string                                            `yaml:"name" json:"name"`
+	Standards map[string]map[string]common.CertificationControl `yaml:"standards" json:"standards"`
 }
 
-// MarshalJSON provides JSON support
+// MarshalJSON provides JSON support. The controls only include the controls with certification data.
 func (certification *Certification) MarshalJSON() (b []byte, e error) {
-	// start the marshaling
-	buffer := bytes.NewBufferString("{")
-
-	// write data
-	buffer.WriteString(fmt.Sprintf("\"key\":\"%s\",\"standards\":", certification.Key))
-	bytes, err := json.Marshal(certification.GetSortedStandards())
-	if err != nil {
-		return nil, err
-	}
-	buffer.WriteString(string(bytes))
+	return json.Marshal(struct {
+		Key       string                                            `json:"key"`
+		Standards []string                                          `json:"standards"`
+		Controls  map[string]map[string]common.CertificationControl `json:"controls,omitempty"`
+	}{certification.Key, certification.GetSortedStandards(), requiredControls(certification.Standards)})
+}
 
-	// done with marshaling
-	buffer.WriteString("}")
-	return buffer.Bytes(), nil
+// requiredControls returns the controls with certification data, by standard and control key.
+func requiredControls(standards map[string]map[string]common.CertificationControl) map[string]map[string]common.CertificationControl {
+	var controls map[string]map[string]common.CertificationControl
+	for standardKey, standardControls := range standards {
+		for controlKey, control := range standardControls {
+			if control.IsEmpty() {
+				continue
+			}
+			if controls == nil {
+				controls = make(map[string]map[string]common.CertificationControl)
+			}
+			if controls[standardKey] == nil {
+				controls[standardKey] = make(map[string]common.CertificationControl)
+			}
+			controls[standardKey][controlKey] = control
+		}
+	}
+	return controls
 }
 
 // GetKey returns the name of the certification.
@@ -61,3 +72,21 @@ func (certification Certification) GetControlKeysFor(standardKey string) []strin
 	sort.Sort(sortorder.Natural(controlNames))
 	return controlNames
 }
+
+// GetControlSource returns the key of the certification since a certification of this version cannot extend another.
+func (certification Certification) GetControlSource(standardKey string, controlKey string) string {
+	return certification.Key
+}
+
+// GetControlRequirements returns the certification data of a control, whatever the spelling of the control key.
+func (certification Certification) GetControlRequirements(standardKey string, controlKey string) common.CertificationControl {
+	if control, found := certification.Standards[standardKey][controlKey]; found {
+		return control
+	}
+	for existingKey, control := range certification.Standards[standardKey] {
+		if controlkeys.Equal(standardKey, existingKey, controlKey) {
+			return control
+		}
+	}
+	return common.CertificationControl{}
+}
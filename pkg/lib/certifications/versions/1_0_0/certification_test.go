@@ -5,7 +5,10 @@
 package certification_test
 
 import (
+	"encoding/json"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/certifications/versions/1_0_0"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -17,19 +20,19 @@ type standardOrderTest struct {
 var standardOrderTests = []standardOrderTest{
 	{
 		// Verify Natural sort order
-		certification.Certification{Standards: map[string]map[string]interface{}{
-			"A": {"3": nil, "2": nil, "1": nil},
-			"B": {"12": nil, "2": nil, "1": nil},
-			"C": {"2": nil, "11": nil, "101": nil, "1000": nil, "100": nil, "10": nil, "1": nil},
+		certification.Certification{Standards: map[string]map[string]common.CertificationControl{
+			"A": {"3": {}, "2": {}, "1": {}},
+			"B": {"12": {}, "2": {}, "1": {}},
+			"C": {"2": {}, "11": {}, "101": {}, "1000": {}, "100": {}, "10": {}, "1": {}},
 		}},
 		"A1A2A3B1B2B12C1C2C10C11C100C101C1000",
 	},
 	{
 		// Check that data is returned in order given letters and numbers
-		certification.Certification{Standards: map[string]map[string]interface{}{
-			"1":  {"3": nil, "2": nil, "1": nil},
-			"B":  {"3": nil, "2": nil, "1": nil},
-			"B2": {"3": nil, "2": nil, "1": nil},
+		certification.Certification{Standards: map[string]map[string]common.CertificationControl{
+			"1":  {"3": {}, "2": {}, "1": {}},
+			"B":  {"3": {}, "2": {}, "1": {}},
+			"B2": {"3": {}, "2": {}, "1": {}},
 		}},
 		"111213B1B2B3B21B22B23",
 	},
@@ -58,3 +61,13 @@ func TestGetKey(t *testing.T) {
 		t.Errorf("GetKey expected test. Actual %s", cert.GetKey())
 	}
 }
+
+func TestMarshalJSON(t *testing.T) {
+	cert := certification.Certification{Key: "test", Standards: map[string]map[string]common.CertificationControl{
+		"A": {"1": {}, "2": {Guidance: "guidance", Parameters: map[string]common.ParameterAssignment{"a": {Value: "value"}}}},
+	}}
+	// Check that only the controls with certification data are written
+	data, err := json.Marshal(&cert)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"key":"test","standards":["A"],"controls":{"A":{"2":{"guidance":"guidance","parameters":{"a":"value"}}}}}`, string(data))
+}
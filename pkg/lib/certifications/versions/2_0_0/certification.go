@@ -0,0 +1,164 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package certification
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+)
+
+// Certification is a certification that can extend another certification and tailor it by adding and removing
+// controls. Schema info: https://github.com/opencontrol/schemas#certifications
+type Certification struct {
+	Key           string `yaml:"name"`
+	SchemaVersion string `yaml:"schema_version"`
+	// Extends is the key of the certification the controls are inherited from.
+	Extends   string                                            `yaml:"extends"`
+	Standards map[string]map[string]common.CertificationControl `yaml:"standards"`
+	// Add and Remove are the control keys added to and removed from the extended certification, by standard.
+	Add    map[string][]string `yaml:"add"`
+	Remove map[string][]string `yaml:"remove"`
+	// Sources is the key of the certification that brought each control, by standard and control key.
+	// It is set by Resolve.
+	Sources map[string]map[string]string `yaml:"-"`
+	// Requirements is the certification data of the controls that have some, by standard and control key.
+	// It is set by Resolve.
+	Requirements map[string]map[string]common.CertificationControl `yaml:"-"`
+}
+
+// MarshalJSON writes the resolved certification like a certification of the version 1.0.0, along with the
+// extended certification and the sources of the controls.
+func (certification *Certification) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Key       string                                            `json:"key"`
+		Standards []string                                          `json:"standards"`
+		Controls  map[string]map[string]common.CertificationControl `json:"controls,omitempty"`
+		Extends   string                                            `json:"extends,omitempty"`
+		Sources   map[string]map[string]string                      `json:"sources"`
+	}{certification.Key, certification.GetSortedStandards(), certification.Requirements, certification.Extends,
+		certification.Sources})
+}
+
+// Resolve computes the controls of the certification from the controls of the extended certification, which is
+// nil when the certification does not extend another one. The certification data of the controls listed under
+// standards replaces the data of the extended certification.
+func (certification *Certification) Resolve(extended common.Certification) error {
+	certification.Sources = make(map[string]map[string]string)
+	certification.Requirements = nil
+	if extended != nil {
+		for _, standardKey := range extended.GetSortedStandards() {
+			for _, controlKey := range extended.GetControlKeysFor(standardKey) {
+				certification.add(standardKey, controlKey, extended.GetControlSource(standardKey, controlKey),
+					extended.GetControlRequirements(standardKey, controlKey))
+			}
+		}
+	}
+	for standardKey, controls := range certification.Standards {
+		for controlKey, control := range controls {
+			certification.add(standardKey, controlKey, certification.Key, control)
+		}
+	}
+	for standardKey, controlKeys := range certification.Add {
+		for _, controlKey := range controlKeys {
+			certification.add(standardKey, controlKey, certification.Key, common.CertificationControl{})
+		}
+	}
+	for standardKey, controlKeys := range certification.Remove {
+		for _, controlKey := range controlKeys {
+			existingKey, found := certification.find(standardKey, controlKey)
+			if !found {
+				return fmt.Errorf("certification %s removes control %s of standard %s, however it is not in the certification",
+					certification.Key, controlKey, standardKey)
+			}
+			delete(certification.Sources[standardKey], existingKey)
+			if len(certification.Sources[standardKey]) == 0 {
+				delete(certification.Sources, standardKey)
+			}
+			delete(certification.Requirements[standardKey], existingKey)
+			if len(certification.Requirements[standardKey]) == 0 {
+				delete(certification.Requirements, standardKey)
+			}
+		}
+	}
+	return nil
+}
+
+// add adds a control unless the certification already has it, whatever its spelling. The certification data of
+// the control is kept when it is not empty, even if the certification already has the control.
+func (certification *Certification) add(standardKey string, controlKey string, source string,
+	control common.CertificationControl) {
+	existingKey, found := certification.find(standardKey, controlKey)
+	if !found {
+		if certification.Sources[standardKey] == nil {
+			certification.Sources[standardKey] = make(map[string]string)
+		}
+		certification.Sources[standardKey][controlKey] = source
+		existingKey = controlKey
+	}
+	if control.IsEmpty() {
+		return
+	}
+	if certification.Requirements == nil {
+		certification.Requirements = make(map[string]map[string]common.CertificationControl)
+	}
+	if certification.Requirements[standardKey] == nil {
+		certification.Requirements[standardKey] = make(map[string]common.CertificationControl)
+	}
+	certification.Requirements[standardKey][existingKey] = control
+}
+
+// find returns the spelling of a control in the certification.
+func (certification Certification) find(standardKey string, controlKey string) (string, bool) {
+	for existingKey := range certification.Sources[standardKey] {
+		if controlkeys.Equal(standardKey, existingKey, controlKey) {
+			return existingKey, true
+		}
+	}
+	return "", false
+}
+
+// GetKey returns the name of the certification.
+func (certification Certification) GetKey() string {
+	return certification.Key
+}
+
+// GetSortedStandards returns the keys of the standards with at least one control, in natural order.
+func (certification Certification) GetSortedStandards() []string {
+	var standardNames []string
+	for standardName := range certification.Sources {
+		standardNames = append(standardNames, standardName)
+	}
+	sort.Sort(sortorder.Natural(standardNames))
+	return standardNames
+}
+
+// GetControlKeysFor returns the control keys of a standard in natural order.
+func (certification Certification) GetControlKeysFor(standardKey string) []string {
+	var controlNames []string
+	for controlName := range certification.Sources[standardKey] {
+		controlNames = append(controlNames, controlName)
+	}
+	sort.Sort(sortorder.Natural(controlNames))
+	return controlNames
+}
+
+// GetControlSource returns the key of the certification that brought the control, i.e. this certification or one
+// of the certifications it extends.
+func (certification Certification) GetControlSource(standardKey string, controlKey string) string {
+	return certification.Sources[standardKey][controlKey]
+}
+
+// GetControlRequirements returns the certification data of a control, whatever the spelling of the control key.
+func (certification Certification) GetControlRequirements(standardKey string, controlKey string) common.CertificationControl {
+	if existingKey, found := certification.find(standardKey, controlKey); found {
+		return certification.Requirements[standardKey][existingKey]
+	}
+	return common.CertificationControl{}
+}
@@ -0,0 +1,58 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package certification
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	certification := Certification{
+		Key:       "internal",
+		Standards: map[string]map[string]common.CertificationControl{"NIST-800-53": {"AC-2": {}}},
+		Add:       map[string][]string{"NIST-800-53": {"AC-02", "CM-2"}},
+		Remove:    map[string][]string{"NIST-800-53": {"cm-02"}},
+	}
+	// Check that controls are only added once, whatever their spelling, and removed whatever their spelling
+	assert.NoError(t, certification.Resolve(nil))
+	assert.Equal(t, []string{"AC-2"}, certification.GetControlKeysFor("NIST-800-53"))
+	assert.Equal(t, "internal", certification.GetControlSource("NIST-800-53", "AC-2"))
+
+	data, err := json.Marshal(&certification)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"key":"internal","standards":["NIST-800-53"],"sources":{"NIST-800-53":{"AC-2":"internal"}}}`, string(data))
+}
+
+func TestResolveRequirements(t *testing.T) {
+	extended := Certification{
+		Key: "moderate",
+		Standards: map[string]map[string]common.CertificationControl{"NIST-800-53": {
+			"AC-2": {Guidance: "Review the accounts monthly."},
+			"AU-2": {Parameters: map[string]common.ParameterAssignment{"a": {Value: "every hour"}}},
+		}},
+	}
+	assert.NoError(t, extended.Resolve(nil))
+	certification := Certification{
+		Key:       "internal",
+		Extends:   "moderate",
+		Standards: map[string]map[string]common.CertificationControl{"NIST-800-53": {"AC-02": {Notes: "Weekly."}}},
+		Remove:    map[string][]string{"NIST-800-53": {"AU-2"}},
+	}
+	// Check that the data of the extended certification is inherited and replaced whatever the spelling
+	assert.NoError(t, certification.Resolve(extended))
+	assert.Equal(t, common.CertificationControl{Notes: "Weekly."}, certification.GetControlRequirements("NIST-800-53", "AC-2"))
+	assert.Equal(t, "moderate", certification.GetControlSource("NIST-800-53", "AC-2"))
+	// Check that the data of removed controls is removed
+	assert.True(t, certification.GetControlRequirements("NIST-800-53", "AU-2").IsEmpty())
+
+	data, err := json.Marshal(&certification)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"key":"internal","standards":["NIST-800-53"],"controls":{"NIST-800-53":{"AC-2":{"notes":"Weekly."}}},`+
+		`"extends":"moderate","sources":{"NIST-800-53":{"AC-2":"moderate"}}}`, string(data))
+}
@@ -4,6 +4,12 @@
 
 package common
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 //go:generate mockery -name Certification
 
 // Certification is the interface for getting all the attributes for a given certification.
@@ -14,8 +20,87 @@ package common
 // GetSortedStandards returns the list of sorted standard keys.
 //
 // GetControlKeysFor returns the list of control keys for a given standard key.
+//
+// GetControlSource returns the key of the certification that requires a control. It is the key of another
+// certification when the control is inherited with `extends`.
+//
+// GetControlRequirements returns what the certification requires for a control, whatever the spelling of the
+// control key. It is empty when the certification does not attach anything to the control.
 type Certification interface {
 	GetKey() string
 	GetSortedStandards() []string
 	GetControlKeysFor(standardKey string) []string
+	GetControlSource(standardKey string, controlKey string) string
+	GetControlRequirements(standardKey string, controlKey string) CertificationControl
+}
+
+// CertificationControl is the data a certification attaches to one of its controls, such as the FedRAMP
+// additional requirements and guidance.
+type CertificationControl struct {
+	// Guidance is the additional requirements and guidance of the certification for the control.
+	Guidance string `yaml:"guidance" json:"guidance,omitempty"`
+	// Parameters are the values required or allowed by the certification, by parameter key.
+	Parameters map[string]ParameterAssignment `yaml:"parameters" json:"parameters,omitempty"`
+	Notes      string                         `yaml:"notes" json:"notes,omitempty"`
+}
+
+// IsEmpty returns true when the certification does not attach anything to the control.
+func (control CertificationControl) IsEmpty() bool {
+	return control.Guidance == "" && len(control.Parameters) == 0 && control.Notes == ""
+}
+
+// ParameterAssignment is what a certification requires for a parameter of a control. In YAML, it is either the
+// required value or a mapping with the required `value` and/or the `allowed` values.
+type ParameterAssignment struct {
+	Value   string   `yaml:"value" json:"value,omitempty"`
+	Allowed []string `yaml:"allowed" json:"allowed,omitempty"`
+}
+
+// UnmarshalYAML reads the required value or the mapping of the assignment.
+func (assignment *ParameterAssignment) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err == nil {
+		*assignment = ParameterAssignment{Value: value}
+		return nil
+	}
+	type plain ParameterAssignment
+	return unmarshal((*plain)(assignment))
+}
+
+// MarshalJSON writes the required value alone when no values are allowed, like in YAML.
+func (assignment ParameterAssignment) MarshalJSON() ([]byte, error) {
+	if len(assignment.Allowed) == 0 {
+		return json.Marshal(assignment.Value)
+	}
+	type plain ParameterAssignment
+	return json.Marshal(plain(assignment))
+}
+
+// Accepts returns true when the value is the required value and one of the allowed values, if any.
+// Values are compared ignoring the case and the surrounding spaces.
+func (assignment ParameterAssignment) Accepts(value string) bool {
+	if assignment.Value != "" && !equalValues(assignment.Value, value) {
+		return false
+	}
+	if len(assignment.Allowed) == 0 {
+		return true
+	}
+	for _, allowed := range assignment.Allowed {
+		if equalValues(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the assignment, e.g. `24 hours` or `one of 12 hours, 24 hours`.
+func (assignment ParameterAssignment) String() string {
+	if assignment.Value != "" || len(assignment.Allowed) == 0 {
+		return assignment.Value
+	}
+	return fmt.Sprintf("one of %s", strings.Join(assignment.Allowed, ", "))
+}
+
+func equalValues(value string, otherValue string) bool {
+	return strings.EqualFold(strings.TrimSpace(value), strings.TrimSpace(otherValue))
 }
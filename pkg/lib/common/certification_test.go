@@ -0,0 +1,57 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package common_test
+
+import (
+	"encoding/json"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	"testing"
+)
+
+type parameterAssignmentTest struct {
+	yaml       string
+	expected   common.ParameterAssignment
+	json       string
+	accepted   []string
+	unaccepted []string
+}
+
+var parameterAssignmentTests = []parameterAssignmentTest{
+	// Check that a scalar is the required value
+	{
+		"24 hours",
+		common.ParameterAssignment{Value: "24 hours"},
+		`"24 hours"`,
+		[]string{"24 hours", " 24 Hours "},
+		[]string{"12 hours", ""},
+	},
+	// Check that a mapping lists the allowed values
+	{
+		"allowed: [12 hours, 24 hours]",
+		common.ParameterAssignment{Allowed: []string{"12 hours", "24 hours"}},
+		`{"allowed":["12 hours","24 hours"]}`,
+		[]string{"12 hours", "24 hours"},
+		[]string{"48 hours"},
+	},
+}
+
+func TestParameterAssignment(t *testing.T) {
+	for _, example := range parameterAssignmentTests {
+		var actual common.ParameterAssignment
+		assert.NoError(t, yaml.Unmarshal([]byte(example.yaml), &actual))
+		assert.Equal(t, example.expected, actual)
+		data, err := json.Marshal(actual)
+		assert.NoError(t, err)
+		assert.JSONEq(t, example.json, string(data))
+		for _, value := range example.accepted {
+			assert.True(t, actual.Accepts(value), value)
+		}
+		for _, value := range example.unaccepted {
+			assert.False(t, actual.Accepts(value), value)
+		}
+	}
+}
@@ -61,6 +61,14 @@ type Component interface {
 // GetImplementationStatus returns the implementation status (only the first one if multiple)
 //
 // GetImplementationStatuses returns all implementation statuses
+//
+// GetResponsibleRoles returns the roles responsible for this particular standard and control
+//
+// GetProviderResponsibility gets the documentation of what the provider of the component is responsible for
+//
+// GetCustomerResponsibility gets the documentation of what the customers of the component are responsible for
+//
+// GetInheritedFrom returns the satisfies entry of another component this one is inherited from, if any
 type Satisfies interface {
 	GetStandardKey() string
 	GetControlKey() string
@@ -71,6 +79,10 @@ type Satisfies interface {
 	GetControlOrigins() []string
 	GetImplementationStatus() string
 	GetImplementationStatuses() []string
+	GetResponsibleRoles() []string
+	GetProviderResponsibility() []Section
+	GetCustomerResponsibility() []Section
+	GetInheritedFrom() InheritedFrom
 }
 
 // Section is a general holder that allows it to be used in something like a map
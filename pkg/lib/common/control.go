@@ -12,8 +12,41 @@ package common
 // GetName returns the string representation of the control.
 //
 // GetFamily returns which family the control belongs to.
+//
+// GetParent returns the key of the control this control enhances, if any.
+//
+// GetChildren returns the sorted keys of the enhancements of the control.
+//
+// GetParts returns the parts of the statement of the control, e.g. a, b and c.
+//
+// GetParameters returns the organization-defined parameters of the control.
+//
+// IsWithdrawn returns true when the control was withdrawn from the standard.
+//
+// GetIncorporatedInto returns the keys of the controls that replace a withdrawn control.
 type Control interface {
 	GetName() string
 	GetFamily() string
 	GetDescription() string
+	GetParent() string
+	GetChildren() []string
+	GetParts() []ControlPart
+	GetParameters() []ControlParameter
+	IsWithdrawn() bool
+	GetIncorporatedInto() []string
+}
+
+// ControlPart is a part of the statement of a control. Parts can have parts of their own, e.g. a.1.
+type ControlPart struct {
+	Key   string        `yaml:"key" json:"key"`
+	Text  string        `yaml:"text" json:"text"`
+	Parts []ControlPart `yaml:"parts" json:"parts,omitempty"`
+}
+
+// ControlParameter is an organization-defined parameter of a control.
+type ControlParameter struct {
+	Key string `yaml:"key" json:"key"`
+	// Label describes the value to define, e.g. organization-defined frequency.
+	Label    string `yaml:"label" json:"label"`
+	Guidance string `yaml:"guidance" json:"guidance,omitempty"`
 }
@@ -0,0 +1,52 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package common
+
+const (
+	// RelationshipEquivalent is for controls that have the same requirements.
+	RelationshipEquivalent = "equivalent"
+	// RelationshipSubset is for a source control whose requirements are part of the requirements of the target control.
+	RelationshipSubset = "subset"
+	// RelationshipSuperset is for a source control whose requirements include the requirements of the target control.
+	RelationshipSuperset = "superset"
+	// RelationshipIntersects is for controls that share some of their requirements.
+	RelationshipIntersects = "intersects"
+)
+
+// Relationships are the relationships a control mapping can have.
+var Relationships = []string{RelationshipEquivalent, RelationshipSubset, RelationshipSuperset, RelationshipIntersects}
+
+// ControlMapping relates a control of a standard to a control of another standard.
+type ControlMapping struct {
+	SourceStandard string `json:"source_standard"`
+	SourceControl  string `json:"source_control"`
+	TargetStandard string `json:"target_standard"`
+	TargetControl  string `json:"target_control"`
+	// Relationship is how the requirements of the source control relate to the requirements of the target control.
+	Relationship string `json:"relationship"`
+}
+
+// Reverse returns the mapping from the target control to the source control.
+func (mapping ControlMapping) Reverse() ControlMapping {
+	relationship := mapping.Relationship
+	switch relationship {
+	case RelationshipSubset:
+		relationship = RelationshipSuperset
+	case RelationshipSuperset:
+		relationship = RelationshipSubset
+	}
+	return ControlMapping{
+		SourceStandard: mapping.TargetStandard,
+		SourceControl:  mapping.TargetControl,
+		TargetStandard: mapping.SourceStandard,
+		TargetControl:  mapping.SourceControl,
+		Relationship:   relationship,
+	}
+}
+
+// IsComplete returns true when the source control covers all the requirements of the target control.
+func (mapping ControlMapping) IsComplete() bool {
+	return mapping.Relationship == RelationshipEquivalent || mapping.Relationship == RelationshipSuperset
+}
@@ -1,6 +1,7 @@
 // Code generated by mockery v1.0.0. DO NOT EDIT.
 package mocks
 
+import common "github.com/opencontrol/compliance-masonry/pkg/lib/common"
 import mock "github.com/stretchr/testify/mock"
 
 // Certification is an autogenerated mock type for the Certification type
@@ -24,6 +25,34 @@ func (_m *Certification) GetControlKeysFor(standardKey string) []string {
 	return r0
 }
 
+// GetControlRequirements provides a mock function with given fields: standardKey, controlKey
+func (_m *Certification) GetControlRequirements(standardKey string, controlKey string) common.CertificationControl {
+	ret := _m.Called(standardKey, controlKey)
+
+	var r0 common.CertificationControl
+	if rf, ok := ret.Get(0).(func(string, string) common.CertificationControl); ok {
+		r0 = rf(standardKey, controlKey)
+	} else {
+		r0 = ret.Get(0).(common.CertificationControl)
+	}
+
+	return r0
+}
+
+// GetControlSource provides a mock function with given fields: standardKey, controlKey
+func (_m *Certification) GetControlSource(standardKey string, controlKey string) string {
+	ret := _m.Called(standardKey, controlKey)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(standardKey, controlKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // GetKey provides a mock function with given fields:
 func (_m *Certification) GetKey() string {
 	ret := _m.Called()
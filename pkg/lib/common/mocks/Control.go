@@ -1,6 +1,7 @@
 // Code generated by mockery v1.0.0. DO NOT EDIT.
 package mocks
 
+import common "github.com/opencontrol/compliance-masonry/pkg/lib/common"
 import mock "github.com/stretchr/testify/mock"
 
 // Control is an autogenerated mock type for the Control type
@@ -8,6 +9,22 @@ type Control struct {
 	mock.Mock
 }
 
+// GetChildren provides a mock function with given fields:
+func (_m *Control) GetChildren() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // GetDescription provides a mock function with given fields:
 func (_m *Control) GetDescription() string {
 	ret := _m.Called()
@@ -36,6 +53,22 @@ func (_m *Control) GetFamily() string {
 	return r0
 }
 
+// GetIncorporatedInto provides a mock function with given fields:
+func (_m *Control) GetIncorporatedInto() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // GetName provides a mock function with given fields:
 func (_m *Control) GetName() string {
 	ret := _m.Called()
@@ -49,3 +82,63 @@ func (_m *Control) GetName() string {
 
 	return r0
 }
+
+// GetParameters provides a mock function with given fields:
+func (_m *Control) GetParameters() []common.ControlParameter {
+	ret := _m.Called()
+
+	var r0 []common.ControlParameter
+	if rf, ok := ret.Get(0).(func() []common.ControlParameter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.ControlParameter)
+		}
+	}
+
+	return r0
+}
+
+// GetParent provides a mock function with given fields:
+func (_m *Control) GetParent() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetParts provides a mock function with given fields:
+func (_m *Control) GetParts() []common.ControlPart {
+	ret := _m.Called()
+
+	var r0 []common.ControlPart
+	if rf, ok := ret.Get(0).(func() []common.ControlPart); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.ControlPart)
+		}
+	}
+
+	return r0
+}
+
+// IsWithdrawn provides a mock function with given fields:
+func (_m *Control) IsWithdrawn() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
@@ -69,6 +69,22 @@ func (_m *Satisfies) GetCoveredBy() common.CoveredByList {
 	return r0
 }
 
+// GetCustomerResponsibility provides a mock function with given fields:
+func (_m *Satisfies) GetCustomerResponsibility() []common.Section {
+	ret := _m.Called()
+
+	var r0 []common.Section
+	if rf, ok := ret.Get(0).(func() []common.Section); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Section)
+		}
+	}
+
+	return r0
+}
+
 // GetImplementationStatus provides a mock function with given fields:
 func (_m *Satisfies) GetImplementationStatus() string {
 	ret := _m.Called()
@@ -99,6 +115,20 @@ func (_m *Satisfies) GetImplementationStatuses() []string {
 	return r0
 }
 
+// GetInheritedFrom provides a mock function with given fields:
+func (_m *Satisfies) GetInheritedFrom() common.InheritedFrom {
+	ret := _m.Called()
+
+	var r0 common.InheritedFrom
+	if rf, ok := ret.Get(0).(func() common.InheritedFrom); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(common.InheritedFrom)
+	}
+
+	return r0
+}
+
 // GetNarratives provides a mock function with given fields:
 func (_m *Satisfies) GetNarratives() []common.Section {
 	ret := _m.Called()
@@ -131,6 +161,38 @@ func (_m *Satisfies) GetParameters() []common.Section {
 	return r0
 }
 
+// GetProviderResponsibility provides a mock function with given fields:
+func (_m *Satisfies) GetProviderResponsibility() []common.Section {
+	ret := _m.Called()
+
+	var r0 []common.Section
+	if rf, ok := ret.Get(0).(func() []common.Section); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Section)
+		}
+	}
+
+	return r0
+}
+
+// GetResponsibleRoles provides a mock function with given fields:
+func (_m *Satisfies) GetResponsibleRoles() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // GetStandardKey provides a mock function with given fields:
 func (_m *Satisfies) GetStandardKey() string {
 	ret := _m.Called()
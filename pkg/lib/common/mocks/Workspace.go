@@ -72,6 +72,45 @@ func (_m *Workspace) GetCertification() common.Certification {
 	return r0
 }
 
+// GetCertificationByKey provides a mock function with given fields: key
+func (_m *Workspace) GetCertificationByKey(key string) (common.Certification, bool) {
+	ret := _m.Called(key)
+
+	var r0 common.Certification
+	if rf, ok := ret.Get(0).(func(string) common.Certification); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Certification)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// GetCertifications provides a mock function with given fields:
+func (_m *Workspace) GetCertifications() []common.Certification {
+	ret := _m.Called()
+
+	var r0 []common.Certification
+	if rf, ok := ret.Get(0).(func() []common.Certification); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Certification)
+		}
+	}
+
+	return r0
+}
+
 // GetComponent provides a mock function with given fields: componentKey
 func (_m *Workspace) GetComponent(componentKey string) (common.Component, bool) {
 	ret := _m.Called(componentKey)
@@ -132,6 +171,22 @@ func (_m *Workspace) LoadCertification(_a0 string) error {
 	return r0
 }
 
+// LoadCertifications provides a mock function with given fields: _a0
+func (_m *Workspace) LoadCertifications(_a0 string) []error {
+	ret := _m.Called(_a0)
+
+	var r0 []error
+	if rf, ok := ret.Get(0).(func(string) []error); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	return r0
+}
+
 // LoadComponents provides a mock function with given fields: _a0
 func (_m *Workspace) LoadComponents(_a0 string) []error {
 	ret := _m.Called(_a0)
@@ -165,3 +220,86 @@ func (_m *Workspace) LoadStandards(_a0 string) []error {
 }
 
 var _ common.Workspace = (*Workspace)(nil)
+
+// SetStatusPolicy provides a mock function with given fields: policy
+func (_m *Workspace) SetStatusPolicy(policy common.StatusPolicy) {
+	_m.Called(policy)
+}
+
+// GetEffectiveStatus provides a mock function with given fields: standardKey, controlKey
+func (_m *Workspace) GetEffectiveStatus(standardKey string, controlKey string) string {
+	ret := _m.Called(standardKey, controlKey)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(standardKey, controlKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// LoadMappings provides a mock function with given fields: mappingsDir
+func (_m *Workspace) LoadMappings(mappingsDir string) []error {
+	ret := _m.Called(mappingsDir)
+
+	var r0 []error
+	if rf, ok := ret.Get(0).(func(string) []error); ok {
+		r0 = rf(mappingsDir)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	return r0
+}
+
+// GetMappingsTo provides a mock function with given fields: targetStandardKey
+func (_m *Workspace) GetMappingsTo(targetStandardKey string) []common.ControlMapping {
+	ret := _m.Called(targetStandardKey)
+
+	var r0 []common.ControlMapping
+	if rf, ok := ret.Get(0).(func(string) []common.ControlMapping); ok {
+		r0 = rf(targetStandardKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.ControlMapping)
+		}
+	}
+
+	return r0
+}
+
+// GetInheritedSatisfies provides a mock function with given fields: satisfies
+func (_m *Workspace) GetInheritedSatisfies(satisfies common.Satisfies) (common.Component, common.Satisfies, bool) {
+	ret := _m.Called(satisfies)
+
+	var r0 common.Component
+	if rf, ok := ret.Get(0).(func(common.Satisfies) common.Component); ok {
+		r0 = rf(satisfies)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Component)
+		}
+	}
+
+	var r1 common.Satisfies
+	if rf, ok := ret.Get(1).(func(common.Satisfies) common.Satisfies); ok {
+		r1 = rf(satisfies)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(common.Satisfies)
+		}
+	}
+
+	var r2 bool
+	if rf, ok := ret.Get(2).(func(common.Satisfies) bool); ok {
+		r2 = rf(satisfies)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	return r0, r1, r2
+}
@@ -41,6 +41,32 @@ type CoveredBy struct {
 //CoveredByList a slice of type CoveredBy
 type CoveredByList []CoveredBy
 
+// InheritedFrom points to the satisfies entry of another component that a satisfies entry is inherited from.
+// The standard and control keys default to the ones of the inheriting entry.
+// This struct is a one-to-one mapping of `inherited_from` in the component.yaml schema 4.0.0
+type InheritedFrom struct {
+	ComponentKey string `yaml:"component_key" json:"component_key"`
+	StandardKey  string `yaml:"standard_key" json:"standard_key,omitempty"`
+	ControlKey   string `yaml:"control_key" json:"control_key,omitempty"`
+}
+
+// IsEmpty returns true when the satisfies entry is not inherited.
+func (inheritedFrom InheritedFrom) IsEmpty() bool {
+	return inheritedFrom.ComponentKey == ""
+}
+
+// GetKeys returns the standard and control keys of the entry inherited from, defaulting to the given keys of the
+// inheriting entry.
+func (inheritedFrom InheritedFrom) GetKeys(standardKey string, controlKey string) (string, string) {
+	if inheritedFrom.StandardKey != "" {
+		standardKey = inheritedFrom.StandardKey
+	}
+	if inheritedFrom.ControlKey != "" {
+		controlKey = inheritedFrom.ControlKey
+	}
+	return standardKey, controlKey
+}
+
 // Len returns the length of the GeneralReferences slice
 func (slice GeneralReferences) Len() int {
 	return len(slice)
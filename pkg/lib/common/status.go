@@ -0,0 +1,14 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package common
+
+// StatusPolicy rolls up the implementation statuses of all the components satisfying a control into the effective
+// status of the control.
+//
+// RollUp returns the effective status given the statuses of all the components. A component without a status
+// contributes an empty status.
+type StatusPolicy interface {
+	RollUp(statuses []string) string
+}
@@ -12,10 +12,18 @@ type Workspace interface {
 	LoadComponents(string) []error
 	LoadStandards(string) []error
 	LoadCertification(string) error
+	LoadCertifications(string) []error
 	GetCertification() Certification
+	GetCertifications() []Certification
+	GetCertificationByKey(key string) (Certification, bool)
 	GetAllComponents() []Component
 	GetComponent(componentKey string) (Component, bool)
 	GetAllStandards() []Standard
 	GetStandard(standardKey string) (Standard, bool)
 	GetAllVerificationsWith(standardKey string, controlKey string) Verifications
+	SetStatusPolicy(policy StatusPolicy)
+	GetEffectiveStatus(standardKey string, controlKey string) string
+	LoadMappings(mappingsDir string) []error
+	GetMappingsTo(targetStandardKey string) []ControlMapping
+	GetInheritedSatisfies(satisfies Satisfies) (Component, Satisfies, bool)
 }
@@ -0,0 +1,158 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	v2 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/2_0_0"
+	v3 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/3_0_0"
+	v31 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/3_1_0"
+	v4 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/4_0_0"
+)
+
+// converter converts a parsed component to the struct of a schema version. It returns the explanations of the
+// values that cannot be carried over.
+type converter func(component common.Component) (common.Component, []string)
+
+// converters are the schema versions components can be converted to.
+var converters = map[string]converter{
+	ComponentV3_1_0.String(): toV3_1_0,
+	ComponentV4_0_0.String(): toV4_0_0,
+}
+
+// ConvertComponent parses the component data and converts it to the struct of the given schema version. It
+// returns the parsed component, the converted one and the explanations of the values that cannot be carried over.
+// Components can only be converted to a newer or the same version.
+func ConvertComponent(componentData []byte, fileName string, version semver.Version) (common.Component, common.Component, []string, error) {
+	convert, found := converters[version.String()]
+	if !found {
+		return nil, nil, nil, fmt.Errorf("Components cannot be converted to the schema version %s", version.String())
+	}
+	component, err := parseComponent(componentData, fileName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if component.GetVersion().GT(version) {
+		return nil, nil, nil, fmt.Errorf("Component %s cannot be converted from the schema version %s to the older version %s",
+			fileName, component.GetVersion().String(), version.String())
+	}
+	converted, explanations := convert(component)
+	converted.SetVersion(version)
+	return component, converted, explanations, nil
+}
+
+// toV3_1_0 converts a component to the schema 3.1.0. The single control origin and implementation status are
+// moved into the lists of control origins and implementation statuses.
+func toV3_1_0(component common.Component) (common.Component, []string) {
+	converted := &v31.Component{
+		Name:          component.GetName(),
+		Key:           component.GetKey(),
+		References:    *component.GetReferences(),
+		Verifications: *component.GetVerifications(),
+	}
+	var explanations []string
+	switch c := component.(type) {
+	case *v2.Component:
+		explanations = append(explanations, fmt.Sprintf("responsible_role is not set, the schema %s has no responsible role.",
+			c.GetVersion().String()))
+		for _, satisfies := range c.Satisfies {
+			s := v31.Satisfies{
+				ControlKey:             satisfies.ControlKey,
+				StandardKey:            satisfies.StandardKey,
+				CoveredBy:              satisfies.CoveredBy,
+				ImplementationStatuses: appendMissing(nil, satisfies.ImplementationStatus),
+			}
+			if satisfies.Narrative != "" {
+				s.Narrative = []v31.NarrativeSection{{Text: string(satisfies.Narrative)}}
+			}
+			converted.Satisfies = append(converted.Satisfies, s)
+		}
+	case *v3.Component:
+		converted.ResponsibleRole = c.ResponsibleRole
+		for _, satisfies := range c.Satisfies {
+			s := v31.Satisfies{
+				ControlKey:             satisfies.ControlKey,
+				StandardKey:            satisfies.StandardKey,
+				CoveredBy:              satisfies.CoveredBy,
+				ControlOrigins:         appendMissing(nil, satisfies.ControlOrigin),
+				ImplementationStatuses: appendMissing(nil, satisfies.ImplementationStatus),
+			}
+			for _, narrative := range satisfies.Narrative {
+				s.Narrative = append(s.Narrative, v31.NarrativeSection{Key: narrative.Key, Text: narrative.Text})
+			}
+			for _, parameter := range satisfies.Parameters {
+				s.Parameters = append(s.Parameters, v31.Section{Key: parameter.Key, Text: parameter.Text})
+			}
+			converted.Satisfies = append(converted.Satisfies, s)
+		}
+	case *v31.Component:
+		converted.ResponsibleRole = c.ResponsibleRole
+		for _, satisfies := range c.Satisfies {
+			satisfies.ControlOrigins = appendMissing(satisfies.ControlOrigins, satisfies.ControlOrigin)
+			satisfies.ImplementationStatuses = appendMissing(satisfies.ImplementationStatuses, satisfies.ImplementationStatus)
+			satisfies.ControlOrigin, satisfies.ImplementationStatus = "", ""
+			converted.Satisfies = append(converted.Satisfies, satisfies)
+		}
+	}
+	return converted, explanations
+}
+
+// toV4_0_0 converts a component to the schema 4.0.0. Older components are converted to the schema 3.1.0 first,
+// the responsibilities and the inherited entries have no counterpart in the older schemas.
+func toV4_0_0(component common.Component) (common.Component, []string) {
+	if c, ok := component.(*v4.Component); ok {
+		converted := *c
+		converted.Satisfies = nil
+		for _, satisfies := range c.Satisfies {
+			satisfies.ControlOrigins = appendMissing(satisfies.ControlOrigins, satisfies.ControlOrigin)
+			satisfies.ImplementationStatuses = appendMissing(satisfies.ImplementationStatuses, satisfies.ImplementationStatus)
+			satisfies.ControlOrigin, satisfies.ImplementationStatus = "", ""
+			converted.Satisfies = append(converted.Satisfies, satisfies)
+		}
+		return &converted, nil
+	}
+	v31Component, explanations := toV3_1_0(component)
+	c := v31Component.(*v31.Component)
+	converted := &v4.Component{
+		Name:            c.Name,
+		Key:             c.Key,
+		References:      c.References,
+		Verifications:   c.Verifications,
+		ResponsibleRole: c.ResponsibleRole,
+	}
+	for _, satisfies := range c.Satisfies {
+		s := v4.Satisfies{
+			ControlKey:             satisfies.ControlKey,
+			StandardKey:            satisfies.StandardKey,
+			CoveredBy:              satisfies.CoveredBy,
+			ControlOrigins:         satisfies.ControlOrigins,
+			ImplementationStatuses: satisfies.ImplementationStatuses,
+		}
+		for _, narrative := range satisfies.Narrative {
+			s.Narrative = append(s.Narrative, v4.NarrativeSection{Key: narrative.Key, Text: narrative.Text})
+		}
+		for _, parameter := range satisfies.Parameters {
+			s.Parameters = append(s.Parameters, v4.Section{Key: parameter.Key, Text: parameter.Text})
+		}
+		converted.Satisfies = append(converted.Satisfies, s)
+	}
+	return converted, explanations
+}
+
+// appendMissing appends the value to the values when it is not empty and not in the values already.
+func appendMissing(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
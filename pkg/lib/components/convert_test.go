@@ -0,0 +1,91 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package components_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/components"
+	v31 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/3_1_0"
+	v4 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/4_0_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var convertComponentTests = []struct {
+	fileName     string
+	satisfies    []v31.Satisfies
+	explanations []string
+}{
+	{
+		filepath.Join("..", "..", "..", "test", "fixtures", "migrate_fixtures", "schema", "v2", "component.yaml"),
+		[]v31.Satisfies{{
+			ControlKey:             "AC-2",
+			StandardKey:            "NIST-800-53",
+			ImplementationStatuses: []string{"complete"},
+			Narrative:              []v31.NarrativeSection{{Text: "Accounts are reviewed every month."}},
+		}},
+		[]string{"responsible_role is not set, the schema 2.0.0 has no responsible role."},
+	},
+	{
+		filepath.Join("..", "..", "..", "test", "fixtures", "migrate_fixtures", "schema", "v3", "component.yaml"),
+		[]v31.Satisfies{
+			{
+				ControlKey:             "CM-2",
+				StandardKey:            "NIST-800-53",
+				CoveredBy:              []common.CoveredBy{{ComponentKey: "UAA", VerificationKey: "UAA_Verification_1"}},
+				ControlOrigins:         []string{"shared"},
+				ImplementationStatuses: []string{"partial"},
+				Narrative:              []v31.NarrativeSection{{Key: "a", Text: "The baseline is kept in the AMI."}},
+			},
+			{
+				ControlKey:  "1.1",
+				StandardKey: "PCI-DSS-MAY-2015",
+				Narrative:   []v31.NarrativeSection{{Text: "Firewall rules are reviewed."}},
+			},
+		},
+		nil,
+	},
+}
+
+func TestConvertComponent(t *testing.T) {
+	for _, example := range convertComponentTests {
+		data, err := ioutil.ReadFile(example.fileName)
+		require.NoError(t, err)
+		_, converted, explanations, err := components.ConvertComponent(data, example.fileName, components.ComponentV3_1_0)
+		require.NoError(t, err)
+		// Check that the component was converted to the struct of the version
+		component, ok := converted.(*v31.Component)
+		require.True(t, ok, example.fileName)
+		assert.Equal(t, example.satisfies, component.Satisfies, example.fileName)
+		assert.Equal(t, components.ComponentV3_1_0, component.GetVersion())
+		assert.Equal(t, example.explanations, explanations, example.fileName)
+	}
+}
+
+func TestConvertComponentToV4(t *testing.T) {
+	fileName := filepath.Join("..", "..", "..", "test", "fixtures", "migrate_fixtures", "schema", "v3", "component.yaml")
+	data, err := ioutil.ReadFile(fileName)
+	require.NoError(t, err)
+	_, converted, explanations, err := components.ConvertComponent(data, fileName, components.ComponentV4_0_0)
+	require.NoError(t, err)
+	component, ok := converted.(*v4.Component)
+	require.True(t, ok)
+	require.Len(t, component.Satisfies, 2)
+	assert.Equal(t, []string{"shared"}, component.Satisfies[0].ControlOrigins)
+	assert.Equal(t, []v4.NarrativeSection{{Key: "a", Text: "The baseline is kept in the AMI."}}, component.Satisfies[0].Narrative)
+	assert.Equal(t, components.ComponentV4_0_0, component.GetVersion())
+	assert.Empty(t, explanations)
+}
+
+func TestConvertComponentUnknownVersion(t *testing.T) {
+	_, _, _, err := components.ConvertComponent([]byte("schema_version: 3.1.0\n"), "component.yaml", semver.MustParse("2.0.0"))
+	assert.Equal(t, errors.New("Components cannot be converted to the schema version 2.0.0"), err)
+}
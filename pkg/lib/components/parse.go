@@ -12,6 +12,7 @@ import (
 	v2 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/2_0_0"
 	v3 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/3_0_0"
 	v31 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/3_1_0"
+	v4 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/4_0_0"
 	"gopkg.in/yaml.v2"
 )
 
@@ -22,6 +23,8 @@ var (
 	ComponentV3_0_0 = semver.MustParse("3.0.0")
 	// ComponentV3_1_0 is a semver representation of version 3.1.0 of component.yaml.
 	ComponentV3_1_0 = semver.MustParse("3.1.0")
+	// ComponentV4_0_0 is a semver representation of version 4.0.0 of component.yaml.
+	ComponentV4_0_0 = semver.MustParse("4.0.0")
 )
 
 func parseComponent(componentData []byte, fileName string) (common.Component, error) {
@@ -50,6 +53,10 @@ func parseComponent(componentData []byte, fileName string) (common.Component, er
 		c := new(v31.Component)
 		err = yaml.Unmarshal(componentData, c)
 		component = c
+	case ComponentV4_0_0.EQ(b.SchemaVersion):
+		c := new(v4.Component)
+		err = yaml.Unmarshal(componentData, c)
+		component = c
 	default:
 		return nil, common.ErrUnknownSchemaVersion
 
@@ -15,10 +15,16 @@ import (
 	v2 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/2_0_0"
 	v3 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/3_0_0"
 	v31 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/3_1_0"
+	v4 "github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/4_0_0"
 	"github.com/opencontrol/compliance-masonry/tools/constants"
 	"github.com/stretchr/testify/assert"
 )
 
+type componentV4Test struct {
+	componentDir string
+	expected     v4.Component
+}
+
 type componentV3_1Test struct {
 	componentDir string
 	expected     v31.Component
@@ -39,6 +45,45 @@ type componentTestError struct {
 	expectedError error
 }
 
+var v4Satisfies = []v4.Satisfies{
+	{
+		ControlKey:             "AC-2",
+		StandardKey:            "NIST-800-53",
+		ImplementationStatuses: []string{"complete"},
+		ControlOrigins:         []string{"shared"},
+		ResponsibleRoles:       []string{"AWS Staff", "Customer account managers"},
+		Narrative: []v4.NarrativeSection{
+			{Text: "Accounts are managed with IAM."},
+		},
+		ProviderResponsibility: []v4.NarrativeSection{
+			{Text: "AWS manages the root accounts of the hypervisors."},
+		},
+		CustomerResponsibility: []v4.NarrativeSection{
+			{Key: "a", Text: "Customers define the types of accounts of their instances."},
+		},
+	},
+	{
+		ControlKey:             "PE-2",
+		StandardKey:            "NIST-800-53",
+		ImplementationStatuses: []string{"complete"},
+		ControlOrigins:         []string{"inherited"},
+		InheritedFrom:          common.InheritedFrom{ComponentKey: "DataCenter"},
+	},
+}
+
+var componentV4Tests = []componentV4Test{
+	// Check that a component with per-control roles and responsibilities loads correctly
+	{filepath.Join("..", "..", "..", "test", "fixtures", "component_fixtures", "v4_0_0", "EC2"), v4.Component{
+		Name:            "Amazon Elastic Compute Cloud",
+		Key:             "EC2",
+		References:      common.GeneralReferences{{}},
+		Verifications:   common.VerificationReferences{{}},
+		Satisfies:       v4Satisfies,
+		SchemaVersion:   semver.MustParse("4.0.0"),
+		ResponsibleRole: "AWS Staff",
+	}},
+}
+
 var v3_1Satisfies = []v31.Satisfies{
 	{
 		Narrative: []v31.NarrativeSection{
@@ -234,6 +279,10 @@ func testSet(example common.Component, actual common.Component, t *testing.T) {
 		assert.Equal(t, (example.GetAllSatisfies())[idx].GetControlOrigins(), (actual.GetAllSatisfies())[idx].GetControlOrigins())
 		assert.Equal(t, (example.GetAllSatisfies())[idx].GetImplementationStatus(), (actual.GetAllSatisfies())[idx].GetImplementationStatus())
 		assert.Equal(t, (example.GetAllSatisfies())[idx].GetImplementationStatuses(), (actual.GetAllSatisfies())[idx].GetImplementationStatuses())
+		assert.Equal(t, (example.GetAllSatisfies())[idx].GetResponsibleRoles(), (actual.GetAllSatisfies())[idx].GetResponsibleRoles())
+		assert.Equal(t, (example.GetAllSatisfies())[idx].GetProviderResponsibility(), (actual.GetAllSatisfies())[idx].GetProviderResponsibility())
+		assert.Equal(t, (example.GetAllSatisfies())[idx].GetCustomerResponsibility(), (actual.GetAllSatisfies())[idx].GetCustomerResponsibility())
+		assert.Equal(t, (example.GetAllSatisfies())[idx].GetInheritedFrom(), (actual.GetAllSatisfies())[idx].GetInheritedFrom())
 	}
 	// Check the responsible role.
 	assert.Equal(t, example.GetResponsibleRole(), actual.GetResponsibleRole())
@@ -254,6 +303,10 @@ func loadValidAndTestComponent(path string, t *testing.T, example common.Compone
 }
 
 func TestLoadComponent(t *testing.T) {
+	// v4_0_0 tests
+	for _, example := range componentV4Tests {
+		loadValidAndTestComponent(example.componentDir, t, &example.expected)
+	}
 	// v3_1_0 tests
 	for _, example := range componentV3_1Tests {
 		loadValidAndTestComponent(example.componentDir, t, &example.expected)
@@ -147,3 +147,23 @@ func (s Satisfies) GetImplementationStatus() string {
 func (s Satisfies) GetImplementationStatuses() []string {
 	return []string{}
 }
+
+// GetResponsibleRoles returns the responsible roles (empty slice for this version)
+func (s Satisfies) GetResponsibleRoles() []string {
+	return []string{}
+}
+
+// GetProviderResponsibility returns the provider responsibility (empty slice for this version)
+func (s Satisfies) GetProviderResponsibility() []common.Section {
+	return []common.Section{}
+}
+
+// GetCustomerResponsibility returns the customer responsibility (empty slice for this version)
+func (s Satisfies) GetCustomerResponsibility() []common.Section {
+	return []common.Section{}
+}
+
+// GetInheritedFrom returns the satisfies entry this one is inherited from (empty for this version)
+func (s Satisfies) GetInheritedFrom() common.InheritedFrom {
+	return common.InheritedFrom{}
+}
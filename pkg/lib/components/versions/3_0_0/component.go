@@ -140,6 +140,26 @@ func (s Satisfies) GetImplementationStatuses() []string {
 	return []string{}
 }
 
+// GetResponsibleRoles returns the responsible roles (empty slice for this version)
+func (s Satisfies) GetResponsibleRoles() []string {
+	return []string{}
+}
+
+// GetProviderResponsibility returns the provider responsibility (empty slice for this version)
+func (s Satisfies) GetProviderResponsibility() []common.Section {
+	return []common.Section{}
+}
+
+// GetCustomerResponsibility returns the customer responsibility (empty slice for this version)
+func (s Satisfies) GetCustomerResponsibility() []common.Section {
+	return []common.Section{}
+}
+
+// GetInheritedFrom returns the satisfies entry this one is inherited from (empty for this version)
+func (s Satisfies) GetInheritedFrom() common.InheritedFrom {
+	return common.InheritedFrom{}
+}
+
 // NarrativeSection contains the key and text for a particular section.
 // NarrativeSection can omit the key.
 type NarrativeSection struct {
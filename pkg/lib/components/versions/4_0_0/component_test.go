@@ -0,0 +1,65 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package component
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentGetters(t *testing.T) {
+	testSatisfies := []Satisfies{
+		{
+			ControlOrigins:         []string{"shared"},
+			ImplementationStatuses: []string{"partial"},
+			Narrative:              []NarrativeSection{{Key: "key", Text: "text"}},
+			ResponsibleRoles:       []string{"AWS Staff", "Customer"},
+			ProviderResponsibility: []NarrativeSection{{Text: "provider"}},
+			CustomerResponsibility: []NarrativeSection{{Key: "a", Text: "customer"}},
+		},
+		{
+			ControlOrigins: []string{"inherited"},
+			InheritedFrom:  common.InheritedFrom{ComponentKey: "DataCenter", ControlKey: "PE-3"},
+		}, {}}
+	component := Component{
+		Name:            "Amazon Elastic Compute Cloud",
+		Key:             "EC2",
+		ResponsibleRole: "AWS Staff",
+		References:      common.GeneralReferences{{}},
+		Verifications:   common.VerificationReferences{{}, {}},
+		Satisfies:       testSatisfies,
+		SchemaVersion:   semver.MustParse("4.0.0"),
+	}
+	// Test the getters
+	assert.Equal(t, "EC2", component.GetKey())
+	assert.Equal(t, "Amazon Elastic Compute Cloud", component.GetName())
+	assert.Equal(t, semver.MustParse("4.0.0"), component.GetVersion())
+	assert.Equal(t, "AWS Staff", component.GetResponsibleRole())
+	satisfies := component.GetAllSatisfies()
+	assert.Equal(t, len(testSatisfies), len(satisfies))
+	assert.Equal(t, []string{"AWS Staff", "Customer"}, satisfies[0].GetResponsibleRoles())
+	assert.Equal(t, "provider", satisfies[0].GetProviderResponsibility()[0].GetText())
+	assert.Equal(t, "a", satisfies[0].GetCustomerResponsibility()[0].GetKey())
+	assert.Equal(t, "customer", satisfies[0].GetCustomerResponsibility()[0].GetText())
+	assert.True(t, satisfies[0].GetInheritedFrom().IsEmpty())
+	assert.Equal(t, "DataCenter", satisfies[1].GetInheritedFrom().ComponentKey)
+	assert.Equal(t, "PE-3", satisfies[1].GetInheritedFrom().ControlKey)
+	assert.Empty(t, satisfies[2].GetResponsibleRoles())
+	assert.Empty(t, satisfies[2].GetProviderResponsibility())
+}
+
+func TestComponentSetters(t *testing.T) {
+	component := Component{}
+	// Test the setters.
+	// Change the version.
+	component.SetVersion(semver.MustParse("4.0.0"))
+	assert.Equal(t, semver.MustParse("4.0.0"), component.GetVersion())
+	// Change the key.
+	component.SetKey("FooKey")
+	assert.Equal(t, "FooKey", component.GetKey())
+}
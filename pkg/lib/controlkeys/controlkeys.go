@@ -0,0 +1,118 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package controlkeys
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+)
+
+// Normalizer converts a control key into its canonical form.
+// Two control keys of the same standard refer to the same control if and only if their canonical forms are equal.
+type Normalizer func(controlKey string) string
+
+var (
+	// nist80053Pattern matches NIST 800-53 style control keys such as "AC-2", "ac-02", "AC-2 (1)", "AC-2(1)" and
+	// "AC-2.1".
+	nist80053Pattern = regexp.MustCompile(`^([A-Za-z]{2})\s*-\s*0*(\d+)(?:\s*(?:\(\s*0*(\d+)\s*\)|\.0*(\d+)))?$`)
+	// whitespacePattern matches consecutive whitespace.
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// Exact is the Normalizer that only trims and collapses the whitespace of control keys.
+func Exact(controlKey string) string {
+	return whitespacePattern.ReplaceAllString(strings.TrimSpace(controlKey), " ")
+}
+
+// NIST80053 is the Normalizer for NIST 800-53 style control keys.
+// The family is upper cased, zero padding is removed and enhancements are written with a space and parentheses
+// (e.g. "ac-02.1" becomes "AC-2 (1)"). Control keys that are not in the NIST 800-53 style are only trimmed.
+func NIST80053(controlKey string) string {
+	key := Exact(controlKey)
+	match := nist80053Pattern.FindStringSubmatch(key)
+	if match == nil {
+		return key
+	}
+	canonical := fmt.Sprintf("%s-%s", strings.ToUpper(match[1]), match[2])
+	if enhancement := match[3] + match[4]; enhancement != "" {
+		canonical = fmt.Sprintf("%s (%s)", canonical, enhancement)
+	}
+	return canonical
+}
+
+// DefaultNormalizer is the Normalizer used for the standards that do not have a registered Normalizer.
+var DefaultNormalizer Normalizer = NIST80053
+
+var registry = struct {
+	normalizers map[string]Normalizer
+	sync.RWMutex
+}{normalizers: make(map[string]Normalizer)}
+
+// Register sets the Normalizer to use for the control keys of the given standard.
+func Register(standardKey string, normalizer Normalizer) {
+	registry.Lock()
+	registry.normalizers[standardKey] = normalizer
+	registry.Unlock()
+}
+
+// For returns the Normalizer to use for the control keys of the given standard.
+func For(standardKey string) Normalizer {
+	registry.RLock()
+	defer registry.RUnlock()
+	if normalizer, found := registry.normalizers[standardKey]; found {
+		return normalizer
+	}
+	return DefaultNormalizer
+}
+
+// Normalize converts the control key of the given standard into its canonical form.
+func Normalize(standardKey string, controlKey string) string {
+	return For(standardKey)(controlKey)
+}
+
+// Equal returns true if both control keys refer to the same control of the given standard.
+func Equal(standardKey string, controlKey string, otherControlKey string) bool {
+	normalize := For(standardKey)
+	return normalize(controlKey) == normalize(otherControlKey)
+}
+
+// Index allows finding the controls of a standard by any spelling of their key.
+type Index struct {
+	standard common.Standard
+	keys     map[string]string
+}
+
+// NewIndex creates an Index of the controls of the given standard.
+func NewIndex(standard common.Standard) Index {
+	normalize := For(standard.GetName())
+	keys := make(map[string]string)
+	for _, controlKey := range standard.GetSortedControls() {
+		normalizedKey := normalize(controlKey)
+		// Keep the first spelling in case the standard itself has several spellings of the same control.
+		if _, exists := keys[normalizedKey]; !exists {
+			keys[normalizedKey] = controlKey
+		}
+	}
+	return Index{standard: standard, keys: keys}
+}
+
+// Find returns the key, as spelled in the standard, of the control that matches the given control key.
+func (index Index) Find(controlKey string) (string, bool) {
+	key, found := index.keys[Normalize(index.standard.GetName(), controlKey)]
+	return key, found
+}
+
+// Get returns the control that matches the given control key.
+func (index Index) Get(controlKey string) (common.Control, bool) {
+	key, found := index.Find(controlKey)
+	if !found {
+		return nil, false
+	}
+	return index.standard.GetControl(key), true
+}
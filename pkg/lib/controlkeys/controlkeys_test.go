@@ -0,0 +1,70 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package controlkeys
+
+import (
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type normalizeTest struct {
+	standardKey string
+	controlKey  string
+	expected    string
+}
+
+var normalizeTests = []normalizeTest{
+	// Check that a canonical NIST 800-53 control is kept as is
+	{"NIST-800-53", "AC-2", "AC-2"},
+	// Check that the family is upper cased and zero padding removed
+	{"NIST-800-53", "ac-02", "AC-2"},
+	// Check that a canonical enhancement is kept as is
+	{"NIST-800-53", "AC-2 (1)", "AC-2 (1)"},
+	// Check that an enhancement without a space is normalized
+	{"NIST-800-53", "AC-2(1)", "AC-2 (1)"},
+	// Check that a dotted enhancement is normalized
+	{"NIST-800-53", "ac-2.1", "AC-2 (1)"},
+	// Check that extra whitespace is removed
+	{"NIST-800-53", " AC-2  (01) ", "AC-2 (1)"},
+	// Check that keys in other styles are only trimmed
+	{"PCI-DSS-MAY-2015", " 1.1.1", "1.1.1"},
+}
+
+func TestNormalize(t *testing.T) {
+	for _, example := range normalizeTests {
+		// Check that the control key is normalized as expected
+		assert.Equal(t, example.expected, Normalize(example.standardKey, example.controlKey))
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("EXACT", Exact)
+	defer Register("EXACT", DefaultNormalizer)
+	// Check that the registered normalizer is used for the standard
+	assert.False(t, Equal("EXACT", "AC-2(1)", "AC-2 (1)"))
+	// Check that the default normalizer is used for other standards
+	assert.True(t, Equal("NIST-800-53", "AC-2(1)", "AC-2 (1)"))
+}
+
+func TestIndex(t *testing.T) {
+	standard := new(mocks.Standard)
+	standard.On("GetName").Return("NIST-800-53")
+	standard.On("GetSortedControls").Return([]string{"AC-2", "AC-2 (1)"})
+	standard.On("GetControl", "AC-2 (1)").Return(new(mocks.Control))
+	index := NewIndex(standard)
+	// Check that a different spelling finds the key of the standard
+	key, found := index.Find("ac-2.1")
+	assert.True(t, found)
+	assert.Equal(t, "AC-2 (1)", key)
+	// Check that the control is returned for a different spelling
+	control, found := index.Get("AC-2(1)")
+	assert.True(t, found)
+	assert.NotNil(t, control)
+	// Check that unknown controls are not found
+	_, found = index.Find("AC-3")
+	assert.False(t, found)
+}
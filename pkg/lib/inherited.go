@@ -0,0 +1,60 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package lib
+
+import (
+	"fmt"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+)
+
+// GetInheritedSatisfies resolves the satisfies entry of the providing component a satisfies entry is inherited from.
+// It returns false when the entry is not inherited or when the providing component does not satisfy the control.
+func (ws *localWorkspace) GetInheritedSatisfies(satisfies common.Satisfies) (common.Component, common.Satisfies, bool) {
+	if satisfies.GetInheritedFrom().IsEmpty() {
+		return nil, nil, false
+	}
+	provider, found := ws.GetComponent(satisfies.GetInheritedFrom().ComponentKey)
+	if !found {
+		return nil, nil, false
+	}
+	standardKey, controlKey := satisfies.GetInheritedFrom().GetKeys(satisfies.GetStandardKey(), satisfies.GetControlKey())
+	for _, providerSatisfies := range provider.GetAllSatisfies() {
+		if providerSatisfies.GetStandardKey() == standardKey &&
+			controlkeys.Equal(standardKey, providerSatisfies.GetControlKey(), controlKey) {
+			return provider, providerSatisfies, true
+		}
+	}
+	return provider, nil, false
+}
+
+// CheckInheritedControls verifies that the satisfies entries inherited from other components can be resolved.
+// An error is returned for each entry whose providing component is not in the workspace or no longer satisfies
+// the control.
+func CheckInheritedControls(ws common.Workspace) []error {
+	var errs []error
+	for _, component := range ws.GetAllComponents() {
+		for _, satisfy := range component.GetAllSatisfies() {
+			if satisfy.GetInheritedFrom().IsEmpty() {
+				continue
+			}
+			provider, _, found := ws.GetInheritedSatisfies(satisfy)
+			if found {
+				continue
+			}
+			providerKey := satisfy.GetInheritedFrom().ComponentKey
+			standardKey, controlKey := satisfy.GetInheritedFrom().GetKeys(satisfy.GetStandardKey(), satisfy.GetControlKey())
+			if provider == nil {
+				errs = append(errs, fmt.Errorf("Component %s inherits control %s of the standard %s from the component %s, however that component does not exist.",
+					component.GetKey(), satisfy.GetControlKey(), satisfy.GetStandardKey(), providerKey))
+				continue
+			}
+			errs = append(errs, fmt.Errorf("Component %s inherits control %s of the standard %s from the component %s, however %s no longer satisfies control %s of the standard %s.",
+				component.GetKey(), satisfy.GetControlKey(), satisfy.GetStandardKey(), providerKey, providerKey, controlKey, standardKey))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,53 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package lib
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInheritedSatisfies(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures_inherited")
+	ws, errs := LoadData(dir, filepath.Join(dir, "certifications", "LATO.yaml"))
+	require.Empty(t, errs)
+	app, found := ws.GetComponent("App")
+	require.True(t, found)
+	satisfies := app.GetAllSatisfies()
+	// Check that entries that are not inherited are not resolved
+	_, _, found = ws.GetInheritedSatisfies(satisfies[0])
+	assert.False(t, found)
+	// Check that the entry of the provider is matched whatever the spelling of the control
+	provider, inherited, found := ws.GetInheritedSatisfies(satisfies[1])
+	require.True(t, found)
+	assert.Equal(t, "AWS", provider.GetKey())
+	assert.Equal(t, "PE-02", inherited.GetControlKey())
+	// Check that the effective status of an inherited control is the status of the provider
+	assert.Equal(t, "complete", ws.GetEffectiveStatus("NIST-800-53", "PE-2"))
+	// Check that missing providers and entries are not resolved
+	provider, _, found = ws.GetInheritedSatisfies(satisfies[2])
+	assert.False(t, found)
+	assert.Nil(t, provider)
+	provider, _, found = ws.GetInheritedSatisfies(satisfies[3])
+	assert.False(t, found)
+	assert.Equal(t, "AWS", provider.GetKey())
+}
+
+func TestCheckInheritedControls(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures_inherited")
+	ws, _ := LoadData(dir, filepath.Join(dir, "certifications", "LATO.yaml"))
+	assert.Equal(t, []error{
+		errors.New("Component App inherits control PE-3 of the standard NIST-800-53 from the component DataCenter, however that component does not exist."),
+		errors.New("Component App inherits control SC-7 of the standard NIST-800-53 from the component AWS, however AWS no longer satisfies control SC-7 of the standard NIST-800-53."),
+	}, CheckInheritedControls(ws))
+	// Check that workspaces without inherited controls have no problems
+	dir = filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures")
+	ws, _ = LoadData(dir, filepath.Join(dir, "certifications", "LATO.yaml"))
+	assert.Empty(t, CheckInheritedControls(ws))
+}
@@ -0,0 +1,49 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package lib
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/mappings"
+)
+
+// LoadMappings loads all the control mappings of the YAML files of a directory into the workspace.
+func (ws *localWorkspace) LoadMappings(mappingsDir string) []error {
+	files, err := filepath.Glob(filepath.Join(mappingsDir, "*.yaml"))
+	if err != nil {
+		return []error{err}
+	}
+	if len(files) == 0 {
+		return []error{fmt.Errorf("Error: No mappings found in `%s`", mappingsDir)}
+	}
+	var errs []error
+	for _, file := range files {
+		controlMappings, err := mappings.Load(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ws.mappings = append(ws.mappings, controlMappings...)
+	}
+	return errs
+}
+
+// GetMappingsTo returns the mappings to the controls of a standard. Mappings from the standard are reversed, so that
+// the target of all the mappings returned is the standard.
+func (ws *localWorkspace) GetMappingsTo(targetStandardKey string) []common.ControlMapping {
+	var controlMappings []common.ControlMapping
+	for _, mapping := range ws.mappings {
+		switch targetStandardKey {
+		case mapping.TargetStandard:
+			controlMappings = append(controlMappings, mapping)
+		case mapping.SourceStandard:
+			controlMappings = append(controlMappings, mapping.Reverse())
+		}
+	}
+	return controlMappings
+}
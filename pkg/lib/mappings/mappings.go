@@ -0,0 +1,65 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+// Package mappings loads the files that map the controls of a standard to the controls of another standard.
+package mappings
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"gopkg.in/yaml.v2"
+)
+
+// file is the format of a mapping file.
+type file struct {
+	Source   string `yaml:"source"`
+	Target   string `yaml:"target"`
+	Mappings []struct {
+		Source       string `yaml:"source"`
+		Target       string `yaml:"target"`
+		Relationship string `yaml:"relationship"`
+	} `yaml:"mappings"`
+}
+
+// Load reads the mappings between the controls of the source and target standards of a mapping file.
+func Load(path string) ([]common.ControlMapping, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, common.ErrReadFile
+	}
+	var mappingFile file
+	if err := yaml.UnmarshalStrict(data, &mappingFile); err != nil {
+		return nil, fmt.Errorf("Unable to parse the mappings %s: %v", path, err)
+	}
+	if mappingFile.Source == "" || mappingFile.Target == "" {
+		return nil, fmt.Errorf("Invalid mappings %s: the source and target standards are required", path)
+	}
+	var mappings []common.ControlMapping
+	for _, mapping := range mappingFile.Mappings {
+		if !isRelationship(mapping.Relationship) {
+			return nil, fmt.Errorf("Invalid mappings %s: unknown relationship '%s' between %s and %s. Use one of the following: %s",
+				path, mapping.Relationship, mapping.Source, mapping.Target, strings.Join(common.Relationships, ", "))
+		}
+		mappings = append(mappings, common.ControlMapping{
+			SourceStandard: mappingFile.Source,
+			SourceControl:  mapping.Source,
+			TargetStandard: mappingFile.Target,
+			TargetControl:  mapping.Target,
+			Relationship:   mapping.Relationship,
+		})
+	}
+	return mappings, nil
+}
+
+func isRelationship(relationship string) bool {
+	for _, known := range common.Relationships {
+		if relationship == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package mappings
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var loadTests = []struct {
+	path     string
+	mappings []common.ControlMapping
+	err      error
+}{
+	{
+		filepath.Join("..", "..", "..", "test", "fixtures", "crosswalk_fixtures", "mappings", "NIST-800-53-to-ISO-27001.yaml"),
+		[]common.ControlMapping{
+			{SourceStandard: "NIST-800-53", SourceControl: "AC-2", TargetStandard: "ISO-27001", TargetControl: "A.9.2.1", Relationship: "equivalent"},
+			{SourceStandard: "NIST-800-53", SourceControl: "AC-6", TargetStandard: "ISO-27001", TargetControl: "A.9.2.3", Relationship: "subset"},
+			{SourceStandard: "NIST-800-53", SourceControl: "CM-2", TargetStandard: "ISO-27001", TargetControl: "A.12.1.2", Relationship: "intersects"},
+		},
+		nil,
+	},
+	{
+		filepath.Join("..", "..", "..", "test", "fixtures", "mappings_fixtures", "unknown-relationship.yaml"),
+		nil,
+		errors.New("Invalid mappings " + filepath.Join("..", "..", "..", "test", "fixtures", "mappings_fixtures", "unknown-relationship.yaml") +
+			": unknown relationship 'same' between AC-2 and A.9.2.1. Use one of the following: equivalent, subset, superset, intersects"),
+	},
+	{
+		filepath.Join("..", "..", "..", "test", "fixtures", "mappings_fixtures", "missing.yaml"),
+		nil,
+		common.ErrReadFile,
+	},
+}
+
+func TestLoad(t *testing.T) {
+	for _, example := range loadTests {
+		mappings, err := Load(example.path)
+		assert.Equal(t, example.err, err, example.path)
+		assert.Equal(t, example.mappings, mappings, example.path)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	mapping := common.ControlMapping{
+		SourceStandard: "NIST-800-53", SourceControl: "AC-6",
+		TargetStandard: "ISO-27001", TargetControl: "A.9.2.3",
+		Relationship: common.RelationshipSubset,
+	}
+	reversed := mapping.Reverse()
+	assert.Equal(t, common.RelationshipSuperset, reversed.Relationship)
+	assert.True(t, reversed.IsComplete())
+	assert.False(t, mapping.IsComplete())
+	assert.Equal(t, mapping, reversed.Reverse())
+}
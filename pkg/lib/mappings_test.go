@@ -0,0 +1,42 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package lib
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMappingsTo(t *testing.T) {
+	ws := NewWorkspace()
+	errs := ws.LoadMappings(filepath.Join("..", "..", "test", "fixtures", "crosswalk_fixtures", "mappings"))
+	assert.Empty(t, errs)
+	// Check that the mappings to the target standard are returned as is
+	toISO := ws.GetMappingsTo("ISO-27001")
+	assert.Equal(t, 3, len(toISO))
+	assert.Equal(t, common.ControlMapping{
+		SourceStandard: "NIST-800-53", SourceControl: "AC-6",
+		TargetStandard: "ISO-27001", TargetControl: "A.9.2.3",
+		Relationship: common.RelationshipSubset,
+	}, toISO[1])
+	// Check that the mappings from the source standard are reversed
+	toNIST := ws.GetMappingsTo("NIST-800-53")
+	assert.Equal(t, 3, len(toNIST))
+	assert.Equal(t, common.ControlMapping{
+		SourceStandard: "ISO-27001", SourceControl: "A.9.2.3",
+		TargetStandard: "NIST-800-53", TargetControl: "AC-6",
+		Relationship: common.RelationshipSuperset,
+	}, toNIST[1])
+	assert.Empty(t, ws.GetMappingsTo("CIS"))
+}
+
+func TestLoadMappingsMissingDir(t *testing.T) {
+	ws := NewWorkspace()
+	errs := ws.LoadMappings(filepath.Join("..", "..", "test", "fixtures", "missing"))
+	assert.Equal(t, 1, len(errs))
+}
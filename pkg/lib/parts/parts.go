@@ -0,0 +1,53 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+// Package parts matches the narrative keys of the components with the parts of the statement of the controls.
+package parts
+
+import (
+	"strings"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+)
+
+// Keys returns the keys of the parts in order. The keys of nested parts are prefixed with the keys of their
+// parents and a dot, e.g. d.1.
+func Keys(parts []common.ControlPart) []string {
+	var keys []string
+	for _, part := range parts {
+		keys = append(keys, part.Key)
+		for _, key := range Keys(part.Parts) {
+			keys = append(keys, part.Key+"."+key)
+		}
+	}
+	return keys
+}
+
+// Match compares the narrative keys with the parts of a control. Missing are the keys of the top level parts that
+// no narrative addresses, directly or through one of their nested parts. Unknown are the narrative keys that are
+// not parts of the control. Narratives without a key do not address any part.
+func Match(parts []common.ControlPart, narratives []common.Section) (missing []string, unknown []string) {
+	known := make(map[string]bool)
+	for _, key := range Keys(parts) {
+		known[key] = true
+	}
+	addressed := make(map[string]bool)
+	for _, narrative := range narratives {
+		key := narrative.GetKey()
+		if key == "" {
+			continue
+		}
+		if !known[key] {
+			unknown = append(unknown, key)
+			continue
+		}
+		addressed[strings.SplitN(key, ".", 2)[0]] = true
+	}
+	for _, part := range parts {
+		if !addressed[part.Key] {
+			missing = append(missing, part.Key)
+		}
+	}
+	return missing, unknown
+}
@@ -0,0 +1,50 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package parts
+
+import (
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/components/versions/3_1_0"
+	"github.com/stretchr/testify/assert"
+)
+
+var controlParts = []common.ControlPart{
+	{Key: "a"},
+	{Key: "b"},
+	{Key: "d", Parts: []common.ControlPart{{Key: "1"}, {Key: "2"}}},
+}
+
+func TestKeys(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "d", "d.1", "d.2"}, Keys(controlParts))
+}
+
+type matchTest struct {
+	narrativeKeys   []string
+	expectedMissing []string
+	expectedUnknown []string
+}
+
+var matchTests = []matchTest{
+	// Check that all the parts can be addressed, including through nested parts
+	{[]string{"a", "b", "d.1"}, nil, nil},
+	// Check that missing and unknown parts are reported
+	{[]string{"a", "e"}, []string{"b", "d"}, []string{"e"}},
+	// Check that a narrative without a key does not address any part
+	{[]string{""}, []string{"a", "b", "d"}, nil},
+}
+
+func TestMatch(t *testing.T) {
+	for _, example := range matchTests {
+		var narratives []common.Section
+		for _, key := range example.narrativeKeys {
+			narratives = append(narratives, component.NarrativeSection{Key: key})
+		}
+		missing, unknown := Match(controlParts, narratives)
+		assert.Equal(t, example.expectedMissing, missing)
+		assert.Equal(t, example.expectedUnknown, unknown)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package query
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+)
+
+// Record is a satisfies item of a component along with its control, if it can be found in the workspace.
+type Record struct {
+	Component common.Component
+	Satisfies common.Satisfies
+	Control   common.Control
+}
+
+// field is a property of a record that can be queried.
+type field struct {
+	values func(record Record) []string
+	// equal compares a value of the field with the value of the query, ignoring the case by default.
+	equal func(record Record, value string, queried string) bool
+	// boolean fields can be queried without operator and value.
+	boolean bool
+}
+
+// fields are the fields of the query language indexed by name.
+var fields = map[string]field{
+	"component": {values: func(r Record) []string {
+		return []string{r.Component.GetKey(), r.Component.GetName()}
+	}},
+	"standard": {values: func(r Record) []string {
+		return []string{r.Satisfies.GetStandardKey()}
+	}},
+	"control": {values: func(r Record) []string {
+		return []string{r.Satisfies.GetControlKey()}
+	}, equal: func(r Record, value string, queried string) bool {
+		// Different spellings of the same control are equal.
+		return controlkeys.Equal(r.Satisfies.GetStandardKey(), value, queried)
+	}},
+	"family": {values: func(r Record) []string {
+		if r.Control == nil {
+			return nil
+		}
+		return []string{r.Control.GetFamily()}
+	}},
+	"status": {values: func(r Record) []string {
+		if statuses := r.Satisfies.GetImplementationStatuses(); len(statuses) > 0 {
+			return statuses
+		}
+		return []string{r.Satisfies.GetImplementationStatus()}
+	}},
+	"control_origin": {values: func(r Record) []string {
+		if origins := r.Satisfies.GetControlOrigins(); len(origins) > 0 {
+			return origins
+		}
+		return []string{r.Satisfies.GetControlOrigin()}
+	}},
+	"responsible_role": {values: func(r Record) []string {
+		return []string{r.Component.GetResponsibleRole()}
+	}},
+	"narrative": {values: func(r Record) []string {
+		var texts []string
+		for _, narrative := range r.Satisfies.GetNarratives() {
+			texts = append(texts, narrative.GetText())
+		}
+		return texts
+	}},
+	"has_narrative": {values: func(r Record) []string {
+		for _, narrative := range r.Satisfies.GetNarratives() {
+			if strings.TrimSpace(narrative.GetText()) != "" {
+				return []string{"true"}
+			}
+		}
+		return []string{"false"}
+	}, equal: equalBool, boolean: true},
+	"parameter": {values: func(r Record) []string {
+		var keys []string
+		for _, parameter := range r.Satisfies.GetParameters() {
+			keys = append(keys, parameter.GetKey())
+		}
+		return keys
+	}},
+}
+
+// matches compares a value of the field with the value of the query.
+func (f field) matches(record Record, value string, queried string) bool {
+	if f.equal == nil {
+		return strings.EqualFold(value, queried)
+	}
+	return f.equal(record, value, queried)
+}
+
+// fieldNames returns the sorted names of the fields.
+func fieldNames() []string {
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// equalBool compares boolean values, accepting the usual spellings (e.g. yes, 1 or TRUE).
+func equalBool(record Record, value string, queried string) bool {
+	switch strings.ToLower(queried) {
+	case "yes", "y":
+		queried = "true"
+	case "no", "n":
+		queried = "false"
+	}
+	expected, err := strconv.ParseBool(queried)
+	if err != nil {
+		return false
+	}
+	actual, _ := strconv.ParseBool(value)
+	return actual == expected
+}
+
+// Find returns the verifications of the workspace matching the query, sorted by component, standard and control.
+func Find(workspace common.Workspace, query string) (common.Verifications, error) {
+	q, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	indexes := make(map[string]controlkeys.Index)
+	var verifications common.Verifications
+	for _, component := range workspace.GetAllComponents() {
+		satisfies := component.GetAllSatisfies()
+		sort.SliceStable(satisfies, func(i, j int) bool {
+			if satisfies[i].GetStandardKey() != satisfies[j].GetStandardKey() {
+				return sortorder.NaturalLess(satisfies[i].GetStandardKey(), satisfies[j].GetStandardKey())
+			}
+			return sortorder.NaturalLess(satisfies[i].GetControlKey(), satisfies[j].GetControlKey())
+		})
+		for _, satisfy := range satisfies {
+			record := Record{Component: component, Satisfies: satisfy}
+			index, found := indexes[satisfy.GetStandardKey()]
+			if !found {
+				if standard, ok := workspace.GetStandard(satisfy.GetStandardKey()); ok {
+					index, found = controlkeys.NewIndex(standard), true
+					indexes[satisfy.GetStandardKey()] = index
+				}
+			}
+			if found {
+				if control, ok := index.Get(satisfy.GetControlKey()); ok {
+					record.Control = control
+				}
+			}
+			if q.Match(record) {
+				verifications = append(verifications, common.Verification{ComponentKey: component.GetKey(), SatisfiesData: satisfy})
+			}
+		}
+	}
+	return verifications, nil
+}
@@ -0,0 +1,255 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/opencontrol/compliance-masonry/tools/suggest"
+)
+
+// Query is a parsed query that can be matched against satisfies records.
+//
+// The query language combines comparisons with the `and`, `or` and `not` operators and parentheses.
+// A comparison is a field, an operator and a value, e.g. `status = partial` or `narrative ~ "encryption"`:
+//
+// `=` matches values that are equal, ignoring the case
+//
+// `!=` matches values that are not equal, ignoring the case
+//
+// `~` matches values that contain the text, ignoring the case
+//
+// `!~` matches values that do not contain the text, ignoring the case
+//
+// Boolean fields, e.g. `has_narrative`, can be used without operator and value.
+// Values with spaces or parentheses must be quoted.
+type Query interface {
+	Match(record Record) bool
+}
+
+type and struct{ left, right Query }
+
+func (q and) Match(record Record) bool { return q.left.Match(record) && q.right.Match(record) }
+
+type or struct{ left, right Query }
+
+func (q or) Match(record Record) bool { return q.left.Match(record) || q.right.Match(record) }
+
+type not struct{ query Query }
+
+func (q not) Match(record Record) bool { return !q.query.Match(record) }
+
+// comparison matches the values of a field.
+type comparison struct {
+	field    field
+	operator string
+	value    string
+}
+
+func (q comparison) Match(record Record) bool {
+	values := q.field.values(record)
+	switch q.operator {
+	case "!=":
+		return !comparison{q.field, "=", q.value}.Match(record)
+	case "!~":
+		return !comparison{q.field, "~", q.value}.Match(record)
+	}
+	for _, value := range values {
+		if q.operator == "=" && q.field.matches(record, value, q.value) ||
+			q.operator == "~" && strings.Contains(strings.ToLower(value), strings.ToLower(q.value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// token is a lexical token of a query.
+type token struct {
+	kind     string
+	text     string
+	position int
+}
+
+const (
+	tokenWord     = "word"
+	tokenString   = "string"
+	tokenOperator = "operator"
+	tokenOpen     = "("
+	tokenClose    = ")"
+	tokenEnd      = "end of query"
+)
+
+// Parse parses the query.
+func Parse(query string) (Query, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	q, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEnd {
+		return nil, p.unexpected(next)
+	}
+	return q, nil
+}
+
+// tokenize splits the query into tokens.
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+	for idx := 0; idx < len(runes); {
+		r := runes[idx]
+		switch {
+		case unicode.IsSpace(r):
+			idx++
+		case r == '(' || r == ')':
+			tokens = append(tokens, token{string(r), string(r), idx})
+			idx++
+		case r == '=' || r == '~':
+			tokens = append(tokens, token{tokenOperator, string(r), idx})
+			idx++
+		case r == '!':
+			if idx+1 == len(runes) || runes[idx+1] != '=' && runes[idx+1] != '~' {
+				return nil, fmt.Errorf("unexpected '!' at position %d", idx+1)
+			}
+			tokens = append(tokens, token{tokenOperator, string(runes[idx : idx+2]), idx})
+			idx += 2
+		case r == '"' || r == '\'':
+			end := idx + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", idx+1)
+			}
+			tokens = append(tokens, token{tokenString, string(runes[idx+1 : end]), idx})
+			idx = end + 1
+		default:
+			end := idx
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("()=~!\"'", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[idx:end]), idx})
+			idx = end
+		}
+	}
+	return append(tokens, token{tokenEnd, "", len(runes)}), nil
+}
+
+// parser is a recursive descent parser of the query tokens.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword returns true if the token is the given keyword.
+func isKeyword(t token, keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEnd {
+		return fmt.Errorf("unexpected end of query")
+	}
+	return fmt.Errorf("unexpected '%s' at position %d", t.text, t.position+1)
+}
+
+func (p *parser) parseOr() (Query, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = or{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Query, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = and{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Query, error) {
+	if isKeyword(p.peek(), "not") {
+		p.next()
+		q, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return not{q}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Query, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenOpen:
+		q, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenClose {
+			return nil, p.unexpected(closing)
+		}
+		return q, nil
+	case t.kind != tokenWord || isKeyword(t, "and") || isKeyword(t, "or"):
+		return nil, p.unexpected(t)
+	}
+	f, found := fields[strings.ToLower(t.text)]
+	if !found {
+		err := fmt.Errorf("unknown field '%s' at position %d", t.text, t.position+1)
+		if didYouMean := suggest.DidYouMean(t.text, fieldNames()); didYouMean != "" {
+			err = fmt.Errorf("%v. %s", err, didYouMean)
+		}
+		return nil, err
+	}
+	if p.peek().kind != tokenOperator {
+		if !f.boolean {
+			return nil, fmt.Errorf("missing operator after '%s' at position %d", t.text, t.position+1)
+		}
+		return comparison{f, "=", "true"}, nil
+	}
+	operator := p.next()
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, p.unexpected(value)
+	}
+	return comparison{f, operator.text, value.text}, nil
+}
@@ -0,0 +1,91 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package query
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type findTest struct {
+	query    string
+	expected []string
+}
+
+var findTests = []findTest{
+	// Check that the component can be queried by key or by name
+	{"component = EC2", []string{"EC2@NIST-800-53@CM-2", "EC2@PCI-DSS-MAY-2015@1.1", "EC2@PCI-DSS-MAY-2015@2.1"}},
+	{`component = "amazon elastic compute cloud" and standard = NIST-800-53`, []string{"EC2@NIST-800-53@CM-2"}},
+	// Check that the control is matched whatever its spelling
+	{"control = cm-02", []string{"EC2@NIST-800-53@CM-2"}},
+	// Check that the family comes from the standard
+	{"family = 2", []string{"EC2@PCI-DSS-MAY-2015@2.1"}},
+	// Check that all the statuses are matched
+	{"status = planned", []string{"EC2@NIST-800-53@CM-2"}},
+	{"status != planned", []string{"EC2@PCI-DSS-MAY-2015@1.1", "EC2@PCI-DSS-MAY-2015@2.1"}},
+	// Check the control origin and the responsible role
+	{"control_origin = inherited and responsible_role ~ aws", []string{"EC2@PCI-DSS-MAY-2015@1.1", "EC2@PCI-DSS-MAY-2015@2.1"}},
+	// Check the boolean fields
+	{"not has_narrative", []string{"EC2@PCI-DSS-MAY-2015@1.1"}},
+	{"has_narrative = no", []string{"EC2@PCI-DSS-MAY-2015@1.1"}},
+	// Check the parameter keys
+	{"parameter = b", []string{"EC2@PCI-DSS-MAY-2015@1.1"}},
+	// Check text matching on the narratives
+	{`narrative ~ "form B"`, []string{"EC2@NIST-800-53@CM-2"}},
+	{`narrative !~ "form"`, []string{"EC2@PCI-DSS-MAY-2015@1.1"}},
+	// Check the precedence of the operators and parentheses
+	{"control = 1.1 or control = 2.1 and has_narrative", []string{"EC2@PCI-DSS-MAY-2015@1.1", "EC2@PCI-DSS-MAY-2015@2.1"}},
+	{"(control = 1.1 or control = 2.1) and has_narrative", []string{"EC2@PCI-DSS-MAY-2015@2.1"}},
+	// Check that no verifications are returned when nothing matches
+	{"status = complete", nil},
+}
+
+func TestFind(t *testing.T) {
+	dir := filepath.Join("..", "..", "..", "test", "fixtures", "opencontrol_fixtures")
+	workspace, errs := lib.LoadData(dir, filepath.Join(dir, "certifications", "LATO.yaml"))
+	require.Empty(t, errs)
+	for _, example := range findTests {
+		verifications, err := Find(workspace, example.query)
+		require.NoError(t, err, example.query)
+		assert.Equal(t, example.expected, keys(verifications), example.query)
+	}
+}
+
+func keys(verifications common.Verifications) []string {
+	var keys []string
+	for _, verification := range verifications {
+		keys = append(keys, verification.ComponentKey+"@"+verification.SatisfiesData.GetStandardKey()+"@"+
+			verification.SatisfiesData.GetControlKey())
+	}
+	return keys
+}
+
+type parseErrorTest struct {
+	query    string
+	expected string
+}
+
+var parseErrorTests = []parseErrorTest{
+	{"stauts = partial", "unknown field 'stauts' at position 1. Did you mean status?"},
+	{"status partial", "missing operator after 'status' at position 1"},
+	{"status =", "unexpected end of query"},
+	{"(status = partial", "unexpected end of query"},
+	{"status = partial control = AC-2", "unexpected 'control' at position 18"},
+	{`narrative ~ "partial`, "unterminated string at position 13"},
+	{"status ! partial", "unexpected '!' at position 8"},
+	{"and status = partial", "unexpected 'and' at position 1"},
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, example := range parseErrorTests {
+		_, err := Parse(example.query)
+		assert.EqualError(t, err, example.expected, example.query)
+	}
+}
@@ -9,9 +9,12 @@ import (
 	"sync"
 
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
 )
 
-// Justifications struct contains the mapping that links controls to specific components
+// Justifications struct contains the mapping that links controls to specific components.
+// Controls are stored by their normalized control key so that different spellings of the same control
+// (e.g. "AC-2 (1)" and "AC-2(1)") share the same verifications.
 type Justifications struct {
 	mapping map[string]map[string]common.Verifications
 	sync.RWMutex
@@ -25,6 +28,7 @@ func NewJustifications() *Justifications {
 // Add methods adds a new mapping to the justification while locking
 func (justifications *Justifications) Add(standardKey string, controlKey string, componentKey string, satisfies common.Satisfies) {
 	justifications.Lock()
+	controlKey = controlkeys.Normalize(standardKey, controlKey)
 	newVerification := common.Verification{
 		ComponentKey:  componentKey,
 		SatisfiesData: satisfies,
@@ -52,6 +56,7 @@ func (justifications *Justifications) LoadMappings(component common.Component) {
 
 // Get retrieves justifications for a specific standard and control
 func (justifications *Justifications) Get(standardKey string, controlKey string) common.Verifications {
+	controlKey = controlkeys.Normalize(standardKey, controlKey)
 	_, standardKeyExists := justifications.mapping[standardKey]
 	if !standardKeyExists {
 		return nil
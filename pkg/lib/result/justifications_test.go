@@ -86,3 +86,14 @@ func TestJustificationGetOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestJustificationGetNormalizedControlKey(t *testing.T) {
+	just := NewJustifications()
+	just.Add("NIST-800-53", "AC-2 (1)", "1", nil)
+	just.Add("NIST-800-53", "AC-2(1)", "2", nil)
+	just.Add("NIST-800-53", "ac-2.1", "3", nil)
+	// Check that the different spellings of the same control are stored together
+	if len(just.Get("NIST-800-53", "AC-02 (1)")) != 3 {
+		t.Errorf("Expected %d, Actual: %d", 3, len(just.Get("NIST-800-53", "AC-02 (1)")))
+	}
+}
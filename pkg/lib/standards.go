@@ -5,9 +5,15 @@
 package lib
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
+	"github.com/fvbommel/sortorder"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/standards"
 )
 
@@ -50,6 +56,16 @@ func (s *standardsMap) getAll() []common.Standard {
 	return standardSlice
 }
 
+// GetStandardKeys returns the sorted keys of all the standards in the workspace.
+func GetStandardKeys(ws common.Workspace) []string {
+	var standardKeys []string
+	for _, standard := range ws.GetAllStandards() {
+		standardKeys = append(standardKeys, standard.GetName())
+	}
+	sort.Sort(sortorder.Natural(standardKeys))
+	return standardKeys
+}
+
 // LoadStandard imports a standard into the Standard struct and adds it to the
 // main object.
 func (ws *localWorkspace) LoadStandard(standardFile string) error {
@@ -70,3 +86,35 @@ func (ws *localWorkspace) GetAllStandards() []common.Standard {
 func (ws *localWorkspace) GetStandard(standardKey string) (common.Standard, bool) {
 	return ws.standards.get(standardKey)
 }
+
+// CheckWithdrawnControls verifies that the components do not satisfy controls that were withdrawn from their standard.
+// An error is returned for each satisfied control that was withdrawn, with the controls that replace it if any.
+func CheckWithdrawnControls(ws common.Workspace) []error {
+	var errs []error
+	indexes := make(map[string]controlkeys.Index)
+	for _, component := range ws.GetAllComponents() {
+		for _, satisfy := range component.GetAllSatisfies() {
+			standardKey := satisfy.GetStandardKey()
+			index, found := indexes[standardKey]
+			if !found {
+				standard, found := ws.GetStandard(standardKey)
+				if !found {
+					continue
+				}
+				index = controlkeys.NewIndex(standard)
+				indexes[standardKey] = index
+			}
+			control, found := index.Get(satisfy.GetControlKey())
+			if !found || !control.IsWithdrawn() {
+				continue
+			}
+			message := fmt.Sprintf("Component %s satisfies control %s of the standard %s, however that control was withdrawn.",
+				component.GetKey(), satisfy.GetControlKey(), standardKey)
+			if incorporatedKeys := control.GetIncorporatedInto(); len(incorporatedKeys) > 0 {
+				message = fmt.Sprintf("%s Use %s instead.", message, strings.Join(incorporatedKeys, " and "))
+			}
+			errs = append(errs, errors.New(message))
+		}
+	}
+	return errs
+}
@@ -5,22 +5,59 @@
 package standards
 
 import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/blang/semver"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
 	v1_0_0 "github.com/opencontrol/compliance-masonry/pkg/lib/standards/versions/1_0_0"
+	v2_0_0 "github.com/opencontrol/compliance-masonry/pkg/lib/standards/versions/2_0_0"
 	"gopkg.in/yaml.v2"
-	"io/ioutil"
+)
+
+var (
+	// StandardV1_0_0 is a semver representation of version 1.0.0 of the standards.
+	StandardV1_0_0 = semver.MustParse("1.0.0")
+	// StandardV2_0_0 is a semver representation of version 2.0.0 of the standards.
+	StandardV2_0_0 = semver.MustParse("2.0.0")
 )
 
 // Load will read the file at the given path and attempt to return a standard object.
+// Standards without a schema version are of the version 1.0.0.
 func Load(path string) (common.Standard, error) {
-	var standard v1_0_0.Standard
 	standardData, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, common.ErrReadFile
 	}
-	err = yaml.Unmarshal(standardData, &standard)
-	if err != nil {
+	var base struct {
+		SchemaVersion string `yaml:"schema_version"`
+	}
+	if err := yaml.Unmarshal(standardData, &base); err != nil {
 		return nil, common.ErrStandardSchema
 	}
-	return standard, nil
+	version := StandardV1_0_0
+	if base.SchemaVersion != "" {
+		if version, err = semver.Parse(base.SchemaVersion); err != nil {
+			return nil, common.ErrCantParseSemver
+		}
+	}
+	switch {
+	case StandardV1_0_0.EQ(version):
+		var standard v1_0_0.Standard
+		if err := yaml.Unmarshal(standardData, &standard); err != nil {
+			return nil, common.ErrStandardSchema
+		}
+		return standard, nil
+	case StandardV2_0_0.EQ(version):
+		var standard v2_0_0.Standard
+		if err := yaml.UnmarshalStrict(standardData, &standard); err != nil {
+			return nil, common.ErrStandardSchema
+		}
+		if err := standard.Link(); err != nil {
+			return nil, fmt.Errorf("%v in %s", err, path)
+		}
+		return standard, nil
+	default:
+		return nil, common.ErrUnknownSchemaVersion
+	}
 }
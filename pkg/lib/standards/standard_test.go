@@ -10,6 +10,7 @@ import (
 
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
 	v1_0_0 "github.com/opencontrol/compliance-masonry/pkg/lib/standards/versions/1_0_0"
+	"github.com/stretchr/testify/assert"
 )
 
 type v1standardsTest struct {
@@ -53,6 +54,8 @@ var standardTestErrors = []standardTestError{
 	{"", common.ErrReadFile},
 	// Check the error loading a file that has a broken schema
 	{filepath.Join("..", "..", "..", "test", "fixtures", "standards_fixtures", "BrokenStandard", "NIST-800-53.yaml"), common.ErrStandardSchema},
+	// Check the error loading a file of a schema version that is not supported
+	{filepath.Join("..", "..", "..", "test", "fixtures", "standards_fixtures", "UnknownVersion", "NIST-800-53-rev5.yaml"), common.ErrUnknownSchemaVersion},
 }
 
 func TestLoadStandardsErrors(t *testing.T) {
@@ -64,3 +67,31 @@ func TestLoadStandardsErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadStandardV2(t *testing.T) {
+	actual, err := Load(filepath.Join("..", "..", "..", "test", "fixtures", "standards_fixtures", "V2Standard", "NIST-800-53-rev5.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "NIST-800-53-rev5", actual.GetName())
+	assert.Equal(t, []string{"AC-2", "AC-2 (1)", "AC-2 (10)", "AC-3"}, actual.GetSortedControls())
+	// Check that the enhancements are linked to the control they enhance
+	control := actual.GetControl("AC-2")
+	assert.Equal(t, []string{"AC-2 (1)", "AC-2 (10)"}, control.GetChildren())
+	assert.Equal(t, "AC-2", actual.GetControl("AC-2 (1)").GetParent())
+	// Check that the parts are loaded with their own parts
+	assert.Equal(t, 3, len(control.GetParts()))
+	assert.Equal(t, []common.ControlPart{
+		{Key: "1", Text: "Authorized users of the system;"},
+		{Key: "2", Text: "Group and role membership; and"},
+	}, control.GetParts()[2].Parts)
+	assert.Equal(t, []common.ControlParameter{{Key: "ac-02_odp.01", Label: "prerequisites and criteria for group and role membership"}},
+		control.GetParameters())
+	assert.True(t, actual.GetControl("AC-2 (10)").IsWithdrawn())
+	assert.Equal(t, []string{"AC-2"}, actual.GetControl("AC-2 (10)").GetIncorporatedInto())
+	assert.False(t, control.IsWithdrawn())
+}
+
+func TestLoadStandardMissingParent(t *testing.T) {
+	path := filepath.Join("..", "..", "..", "test", "fixtures", "standards_fixtures", "MissingParent", "NIST-800-53-rev5.yaml")
+	_, err := Load(path)
+	assert.EqualError(t, err, "control AC-2 (1) of standard NIST-800-53-rev5 enhances AC-2, however that cannot be found in the standard in "+path)
+}
@@ -22,8 +22,9 @@ type Control struct {
 // Standard struct is a collection of security requirements
 // Schema info: https://github.com/opencontrol/schemas#standards-documentation
 type Standard struct {
-	Name     string             `yaml:"name" json:"name"`
-	Controls map[string]Control `yaml:",inline"`
+	SchemaVersion string             `yaml:"schema_version" json:"-"`
+	Name          string             `yaml:"name" json:"name"`
+	Controls      map[string]Control `yaml:",inline"`
 }
 
 // GetSortedControls returns a list of sorted controls
@@ -69,3 +70,33 @@ func (control Control) GetName() string {
 func (control Control) GetDescription() string {
 	return control.Description
 }
+
+// GetParent returns an empty key since controls of this version cannot have a parent.
+func (control Control) GetParent() string {
+	return ""
+}
+
+// GetChildren returns no keys since controls of this version cannot have enhancements.
+func (control Control) GetChildren() []string {
+	return nil
+}
+
+// GetParts returns no parts since the statement of controls of this version is only a description.
+func (control Control) GetParts() []common.ControlPart {
+	return nil
+}
+
+// GetParameters returns no parameters since controls of this version do not define them.
+func (control Control) GetParameters() []common.ControlParameter {
+	return nil
+}
+
+// IsWithdrawn returns false since controls of this version cannot be withdrawn.
+func (control Control) IsWithdrawn() bool {
+	return false
+}
+
+// GetIncorporatedInto returns no keys since controls of this version cannot be withdrawn.
+func (control Control) GetIncorporatedInto() []string {
+	return nil
+}
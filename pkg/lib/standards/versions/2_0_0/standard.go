@@ -0,0 +1,138 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package standard
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+)
+
+// Control struct stores data on a specific security requirement, its statement parts and its parameters.
+// Enhancements are controls of their own that reference the control they enhance with Parent.
+type Control struct {
+	Family      string                    `yaml:"family" json:"family"`
+	Name        string                    `yaml:"name" json:"name"`
+	Description string                    `yaml:"description" json:"description"`
+	Parent      string                    `yaml:"parent" json:"parent,omitempty"`
+	Parts       []common.ControlPart      `yaml:"parts" json:"parts,omitempty"`
+	Parameters  []common.ControlParameter `yaml:"parameters" json:"parameters,omitempty"`
+	Withdrawn   bool                      `yaml:"withdrawn" json:"withdrawn,omitempty"`
+	// IncorporatedInto are the keys of the controls that replace a withdrawn control.
+	IncorporatedInto []string `yaml:"incorporated_into" json:"incorporated_into,omitempty"`
+	// Children are the keys of the enhancements of the control. They are set by Link.
+	Children []string `yaml:"-" json:"children,omitempty"`
+}
+
+// Standard struct is a collection of security requirements
+type Standard struct {
+	SchemaVersion string             `yaml:"schema_version" json:"-"`
+	Name          string             `yaml:"name" json:"name"`
+	Controls      map[string]Control `yaml:"controls" json:"controls"`
+}
+
+// Link sets the children of the controls from the parents of their enhancements. The parents and the controls
+// incorporating withdrawn controls must be controls of the standard.
+func (standard *Standard) Link() error {
+	children := make(map[string][]string)
+	for controlKey, control := range standard.Controls {
+		for _, incorporatedKey := range control.IncorporatedInto {
+			if _, found := standard.Controls[incorporatedKey]; !found {
+				return fmt.Errorf("control %s of standard %s is incorporated into %s, however that cannot be found in the standard",
+					controlKey, standard.Name, incorporatedKey)
+			}
+		}
+		if control.Parent == "" {
+			continue
+		}
+		if _, found := standard.Controls[control.Parent]; !found {
+			return fmt.Errorf("control %s of standard %s enhances %s, however that cannot be found in the standard",
+				controlKey, standard.Name, control.Parent)
+		}
+		children[control.Parent] = append(children[control.Parent], controlKey)
+	}
+	for controlKey, control := range standard.Controls {
+		control.Children = children[controlKey]
+		sort.Sort(sortorder.Natural(control.Children))
+		standard.Controls[controlKey] = control
+	}
+	return nil
+}
+
+// GetSortedControls returns a list of sorted controls
+func (standard Standard) GetSortedControls() []string {
+	var controlNames []string
+	for controlName := range standard.Controls {
+		controlNames = append(controlNames, controlName)
+	}
+	sort.Sort(sortorder.Natural(controlNames))
+	return controlNames
+}
+
+// GetName returns the name of the standard.
+func (standard Standard) GetName() string {
+	return standard.Name
+}
+
+// GetControls returns all controls associated with the standard
+func (standard Standard) GetControls() map[string]common.Control {
+	m := make(map[string]common.Control)
+	for key, value := range standard.Controls {
+		m[key] = value
+	}
+	return m
+}
+
+// GetControl returns a particular control
+func (standard Standard) GetControl(controlKey string) common.Control {
+	return standard.Controls[controlKey]
+}
+
+// GetFamily returns which family the control belongs to.
+func (control Control) GetFamily() string {
+	return control.Family
+}
+
+// GetName returns the string representation of the control.
+func (control Control) GetName() string {
+	return control.Name
+}
+
+// GetDescription returns the string description of the control.
+func (control Control) GetDescription() string {
+	return control.Description
+}
+
+// GetParent returns the key of the control this control enhances, if any.
+func (control Control) GetParent() string {
+	return control.Parent
+}
+
+// GetChildren returns the sorted keys of the enhancements of the control.
+func (control Control) GetChildren() []string {
+	return control.Children
+}
+
+// GetParts returns the parts of the statement of the control.
+func (control Control) GetParts() []common.ControlPart {
+	return control.Parts
+}
+
+// GetParameters returns the organization-defined parameters of the control.
+func (control Control) GetParameters() []common.ControlParameter {
+	return control.Parameters
+}
+
+// IsWithdrawn returns true when the control was withdrawn from the standard.
+func (control Control) IsWithdrawn() bool {
+	return control.Withdrawn
+}
+
+// GetIncorporatedInto returns the keys of the controls that replace the control when it was withdrawn.
+func (control Control) GetIncorporatedInto() []string {
+	return control.IncorporatedInto
+}
@@ -0,0 +1,32 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package standard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLink(t *testing.T) {
+	standard := Standard{Name: "NIST-800-53-rev5", Controls: map[string]Control{
+		"AC-2":      {},
+		"AC-2 (11)": {Parent: "AC-2"},
+		"AC-2 (2)":  {Parent: "AC-2"},
+		"AC-3":      {},
+	}}
+	// Check that the children are sorted in natural order
+	assert.NoError(t, standard.Link())
+	assert.Equal(t, []string{"AC-2 (2)", "AC-2 (11)"}, standard.GetControl("AC-2").GetChildren())
+	assert.Empty(t, standard.GetControl("AC-3").GetChildren())
+}
+
+func TestLinkIncorporatedInto(t *testing.T) {
+	standard := Standard{Name: "NIST-800-53-rev5", Controls: map[string]Control{
+		"AC-2 (10)": {Withdrawn: true, IncorporatedInto: []string{"AC-2 (99)"}},
+	}}
+	// Check that withdrawn controls must be incorporated into controls of the standard
+	assert.EqualError(t, standard.Link(), "control AC-2 (10) of standard NIST-800-53-rev5 is incorporated into AC-2 (99), however that cannot be found in the standard")
+}
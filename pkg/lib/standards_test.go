@@ -5,9 +5,11 @@
 package lib
 
 import (
+	"errors"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common/mocks"
 	"github.com/stretchr/testify/assert"
+	"path/filepath"
 	"testing"
 )
 
@@ -37,3 +39,17 @@ func TestBadLoadStandard(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, common.ErrStandardSchema, err)
 }
+
+func TestCheckWithdrawnControls(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures_rev5")
+	ws, errs := LoadData(dir, filepath.Join(dir, "certifications", "LATO.yaml"))
+	assert.Empty(t, errs)
+	// Check that the withdrawn controls are reported with the controls that replace them
+	assert.Equal(t, []error{
+		errors.New("Component Database satisfies control AC-2 (10) of the standard NIST-800-53-rev5, however that control was withdrawn. Use AC-2 instead."),
+	}, CheckWithdrawnControls(ws))
+	// Check that standards without withdrawn controls have no problems
+	dir = filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures")
+	ws, _ = LoadData(dir, filepath.Join(dir, "certifications", "LATO.yaml"))
+	assert.Empty(t, CheckWithdrawnControls(ws))
+}
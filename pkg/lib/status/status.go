@@ -0,0 +1,137 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package status
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Missing is the effective status of the controls that are not satisfied by any component.
+	Missing = "missing"
+	// Unknown is the status of the components that do not have a status.
+	Unknown = "unknown"
+)
+
+// Rule sets the effective status of a control when the statuses of its components match.
+// Exactly one of All or Any must be set.
+type Rule struct {
+	// All matches when all the statuses are in the list.
+	All []string `yaml:"all"`
+	// Any matches when at least one of the statuses is in the list.
+	Any []string `yaml:"any"`
+	// Status is the effective status when the rule matches.
+	Status string `yaml:"status"`
+}
+
+// Policy rolls up the statuses of the components satisfying a control with the first rule that matches them.
+// It implements common.StatusPolicy.
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+	// Default is the effective status when no rule matches.
+	Default string `yaml:"default"`
+}
+
+// DefaultPolicy is the policy used when none is configured.
+var DefaultPolicy = Policy{
+	Rules: []Rule{
+		{All: []string{"not applicable"}, Status: "not applicable"},
+		{All: []string{"complete", "not applicable"}, Status: "complete"},
+		{All: []string{"planned"}, Status: "planned"},
+		{Any: []string{"complete", "partial", "planned"}, Status: "partial"},
+		{All: []string{"none"}, Status: "none"},
+		{All: []string{"unsatisfied", "none"}, Status: "unsatisfied"},
+	},
+	Default: Unknown,
+}
+
+// RollUp returns the effective status given the statuses of all the components satisfying a control.
+func (p Policy) RollUp(statuses []string) string {
+	if len(statuses) == 0 {
+		return Missing
+	}
+	known := make([]string, len(statuses))
+	for idx, status := range statuses {
+		known[idx] = status
+		if status == "" {
+			known[idx] = Unknown
+		}
+	}
+	for _, rule := range p.Rules {
+		if rule.matches(known) {
+			return rule.Status
+		}
+	}
+	return p.Default
+}
+
+func (r Rule) matches(statuses []string) bool {
+	if len(r.All) > 0 {
+		for _, status := range statuses {
+			if !contains(r.All, status) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, status := range statuses {
+		if contains(r.Any, status) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the rules of the policy are well formed.
+func (p Policy) Validate() error {
+	if p.Default == "" {
+		return errors.New("the default status of the policy is missing")
+	}
+	for idx, rule := range p.Rules {
+		if len(rule.All) > 0 == (len(rule.Any) > 0) {
+			return fmt.Errorf("rule %d of the policy must have either `all` or `any` statuses", idx+1)
+		}
+		if rule.Status == "" {
+			return fmt.Errorf("rule %d of the policy is missing its status", idx+1)
+		}
+	}
+	return nil
+}
+
+// LoadPolicy loads a policy from a YAML file, e.g.
+//
+//	rules:
+//	  - all: [complete, not applicable]
+//	    status: complete
+//	  - any: [partial, planned]
+//	    status: partial
+//	default: unknown
+func LoadPolicy(path string) (Policy, error) {
+	var policy Policy
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return policy, fmt.Errorf("Unable to read the status policy %s: %v", path, err)
+	}
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return policy, fmt.Errorf("Unable to parse the status policy %s: %v", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return policy, fmt.Errorf("Invalid status policy %s: %v", path, err)
+	}
+	return policy, nil
+}
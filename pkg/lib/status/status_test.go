@@ -0,0 +1,82 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package status
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type rollUpTest struct {
+	statuses []string
+	expected string
+}
+
+var rollUpTests = []rollUpTest{
+	// Check that controls without components are missing
+	{nil, Missing},
+	// Check that not applicable components do not prevent a control from being complete
+	{[]string{"not applicable"}, "not applicable"},
+	{[]string{"complete", "not applicable"}, "complete"},
+	// Check that any progress makes the control partial
+	{[]string{"planned"}, "planned"},
+	{[]string{"complete", "planned"}, "partial"},
+	{[]string{"none", "partial"}, "partial"},
+	{[]string{"none", "unsatisfied"}, "unsatisfied"},
+	// Check that components without a status are unknown
+	{[]string{""}, Unknown},
+	{[]string{"complete", ""}, "partial"},
+}
+
+func TestRollUp(t *testing.T) {
+	for _, example := range rollUpTests {
+		assert.Equal(t, example.expected, DefaultPolicy.RollUp(example.statuses), "%v", example.statuses)
+	}
+}
+
+type loadPolicyTest struct {
+	path          string
+	statuses      []string
+	expected      string
+	expectedError string
+}
+
+var loadPolicyTests = []loadPolicyTest{
+	// Check that the rules of the policy are applied in order
+	{"policy.yaml", []string{"complete", "planned"}, "planned", ""},
+	{"policy.yaml", []string{"complete", "partial"}, "partial", ""},
+	{"policy.yaml", []string{"unsatisfied"}, "none", ""},
+	// Check that rules with both all and any statuses are rejected
+	{"invalid_policy.yaml", nil, "", "Invalid status policy %s: rule 1 of the policy must have either `all` or `any` statuses"},
+	// Check that a missing policy is reported
+	{"missing.yaml", nil, "", "Unable to read the status policy %s: open %s: no such file or directory"},
+}
+
+func TestLoadPolicy(t *testing.T) {
+	for _, example := range loadPolicyTests {
+		path := filepath.Join("..", "..", "..", "test", "fixtures", "status_fixtures", example.path)
+		policy, err := LoadPolicy(path)
+		if example.expectedError != "" {
+			assert.EqualError(t, err, replacePath(example.expectedError, path))
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, example.expected, policy.RollUp(example.statuses), "%v", example.statuses)
+	}
+}
+
+func replacePath(message string, path string) string {
+	return strings.Replace(message, "%s", path, -1)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, DefaultPolicy.Validate())
+	assert.EqualError(t, Policy{}.Validate(), "the default status of the policy is missing")
+	assert.EqualError(t, Policy{Rules: []Rule{{All: []string{"complete"}}}, Default: Unknown}.Validate(),
+		"rule 1 of the policy is missing its status")
+}
@@ -14,15 +14,19 @@ import (
 	"github.com/opencontrol/compliance-masonry/pkg/cli/clierrors"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/result"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/status"
 )
 
-// localWorkspace struct combines components, standards, and a certification data
+// localWorkspace struct combines components, standards, and certifications data
 // For more information on the opencontrol schema visit: https://github.com/opencontrol/schemas
 type localWorkspace struct {
 	components     *componentsMap
 	standards      *standardsMap
 	justifications *result.Justifications
 	certification  common.Certification
+	certifications map[string]common.Certification
+	statusPolicy   common.StatusPolicy
+	mappings       []common.ControlMapping
 }
 
 // getKey extracts a component key from the filepath
@@ -37,6 +41,8 @@ func NewWorkspace() common.Workspace {
 		justifications: result.NewJustifications(),
 		components:     newComponents(),
 		standards:      newStandards(),
+		certifications: make(map[string]common.Certification),
+		statusPolicy:   status.DefaultPolicy,
 	}
 }
 
@@ -72,6 +78,35 @@ func LoadData(openControlDir string, certificationPath string) (common.Workspace
 	return ws, errs
 }
 
+// LoadAllData creates a new instance of OpenControl struct and loads the components, the standards and
+// every certification of the `certifications` directory. GetCertification returns nil for such a workspace.
+func LoadAllData(openControlDir string) (common.Workspace, []error) {
+	var wg sync.WaitGroup
+	ws := NewWorkspace()
+	wg.Add(3)
+	var componentsErrs, standardsErrs, certificationsErrs []error
+	go func(wg *sync.WaitGroup) {
+		defer wg.Done()
+		componentsErrs = ws.LoadComponents(filepath.Join(openControlDir, "components"))
+	}(&wg)
+	go func(wg *sync.WaitGroup) {
+		defer wg.Done()
+		standardsErrs = ws.LoadStandards(filepath.Join(openControlDir, "standards"))
+	}(&wg)
+	go func(wg *sync.WaitGroup) {
+		defer wg.Done()
+		certificationsErrs = ws.LoadCertifications(filepath.Join(openControlDir, "certifications"))
+	}(&wg)
+	wg.Wait()
+
+	var errs []error
+	errs = append(errs, certificationsErrs...)
+	errs = append(errs, componentsErrs...)
+	errs = append(errs, standardsErrs...)
+
+	return ws, errs
+}
+
 // LoadComponents loads multiple components by searching for components in a
 // given directory
 func (ws *localWorkspace) LoadComponents(directory string) []error {
@@ -130,3 +165,43 @@ func convertErrChannelToErrorSlice(errs <-chan error) []error {
 func (ws *localWorkspace) GetAllVerificationsWith(standardKey string, controlKey string) common.Verifications {
 	return ws.justifications.Get(standardKey, controlKey)
 }
+
+// SetStatusPolicy sets the policy used to roll up the statuses of the components into the effective status of a control.
+func (ws *localWorkspace) SetStatusPolicy(policy common.StatusPolicy) {
+	ws.statusPolicy = policy
+}
+
+// GetEffectiveStatus rolls up the statuses of all the components satisfying a control with the status policy.
+// Inherited controls count with the statuses of the component they are inherited from.
+func (ws *localWorkspace) GetEffectiveStatus(standardKey string, controlKey string) string {
+	var statuses []string
+	for _, verification := range ws.GetAllVerificationsWith(standardKey, controlKey) {
+		satisfies := verification.SatisfiesData
+		// Inherited entries without a status of their own take the statuses of the providing entry.
+		if len(satisfies.GetImplementationStatuses()) == 0 && satisfies.GetImplementationStatus() == "" {
+			if _, inherited, found := ws.GetInheritedSatisfies(satisfies); found {
+				satisfies = inherited
+			}
+		}
+		if componentStatuses := satisfies.GetImplementationStatuses(); len(componentStatuses) > 0 {
+			statuses = append(statuses, componentStatuses...)
+		} else {
+			statuses = append(statuses, satisfies.GetImplementationStatus())
+		}
+	}
+	return ws.statusPolicy.RollUp(statuses)
+}
+
+// LoadStatusPolicy sets the status policy of the workspace from a YAML file. The default policy is kept when the
+// file is empty.
+func LoadStatusPolicy(ws common.Workspace, policyFile string) error {
+	if policyFile == "" {
+		return nil
+	}
+	policy, err := status.LoadPolicy(policyFile)
+	if err != nil {
+		return err
+	}
+	ws.SetStatusPolicy(policy)
+	return nil
+}
@@ -175,3 +175,84 @@ func findNumberOfStandards(actual common.Workspace, standardKeys []string) int {
 	}
 	return actualStandardsNum
 }
+
+type checkCertificationTest struct {
+	certificationPath string
+	expectedErrors    []error
+}
+
+var checkCertificationTests = []checkCertificationTest{
+	// Check that a certification that only references known standards and controls has no problems
+	{
+		filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures", "certifications", "LATO.yaml"),
+		nil,
+	},
+	// Check that missing standards and missing controls are reported with the closest keys
+	// and that a different spelling of a control (AC-02) is not reported
+	{
+		filepath.Join("..", "..", "test", "fixtures", "certification_fixtures", "LATO-typos.yaml"),
+		[]error{
+			errors.New("Certification LATO-typos references control AC-2 (99), however that cannot be found in the standard NIST-800-53. Did you mean AC-2 (9), AC-2 (1) or AC-2 (2)?"),
+			errors.New("Certification LATO-typos references standard NIST-800-54, however that cannot be found in the workspace. Did you mean NIST-800-53?"),
+		},
+	},
+}
+
+func TestCheckCertification(t *testing.T) {
+	for _, example := range checkCertificationTests {
+		ws, _ := LoadData(filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures"), example.certificationPath)
+		// Check that the actual problems are the expected problems
+		assert.Equal(t, example.expectedErrors, CheckCertification(ws))
+	}
+}
+
+type effectiveStatusTest struct {
+	statusPolicy string
+	controlKey   string
+	expected     string
+}
+
+var effectiveStatusTests = []effectiveStatusTest{
+	// Check that the statuses of the components are rolled up with the default policy
+	{"", "CM-2", "partial"},
+	{"", "AC-2", "missing"},
+	// Check that the policy can be configured
+	{filepath.Join("..", "..", "test", "fixtures", "status_fixtures", "policy.yaml"), "CM-2", "planned"},
+}
+
+func TestGetEffectiveStatus(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures")
+	for _, example := range effectiveStatusTests {
+		ws, _ := LoadData(dir, filepath.Join(dir, "certifications", "LATO.yaml"))
+		assert.NoError(t, LoadStatusPolicy(ws, example.statusPolicy))
+		assert.Equal(t, example.expected, ws.GetEffectiveStatus("NIST-800-53", example.controlKey))
+	}
+}
+
+func TestLoadAllData(t *testing.T) {
+	// Check that all the certifications are loaded by file name along with the components and standards
+	ws, errs := LoadAllData(filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures"))
+	assert.Empty(t, errs)
+	assert.Nil(t, ws.GetCertification())
+	assert.Equal(t, 2, len(ws.GetCertifications()))
+	assert.Equal(t, "LATO", ws.GetCertifications()[0].GetKey())
+	certification, found := ws.GetCertificationByKey("LATO-base")
+	assert.True(t, found)
+	assert.Equal(t, []string{"AC-02"}, certification.GetControlKeysFor("NIST-800-53"))
+	assert.Equal(t, 1, len(ws.GetAllComponents()))
+	// Check that a directory without certifications is reported
+	ws = NewWorkspace()
+	errs = ws.LoadCertifications(filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures", "components"))
+	assert.Equal(t, 1, len(errs))
+}
+
+func TestGetCertificationByKey(t *testing.T) {
+	// Check that the certification of LoadData can also be found by key
+	dir := filepath.Join("..", "..", "test", "fixtures", "opencontrol_fixtures")
+	ws, _ := LoadData(dir, filepath.Join(dir, "certifications", "LATO.yaml"))
+	certification, found := ws.GetCertificationByKey("LATO")
+	assert.True(t, found)
+	assert.Equal(t, ws.GetCertification(), certification)
+	_, found = ws.GetCertificationByKey("LATO-base")
+	assert.False(t, found)
+}
@@ -11,6 +11,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/opencontrol/compliance-masonry/tools/suggest"
 )
 
 // GetCertification will look for the specified certification and will return the path to the
@@ -28,10 +30,16 @@ func GetCertification(opencontrolDir string, certification string) (string, []er
 		if err != nil {
 			return "", []error{errors.New("Error: `" + certificationDir + "` directory does exist")}
 		}
-		errMessage := fmt.Sprintf("Error: `%s` does not exist\nUse one of the following:", certificationPath)
+		var certificationNames []string
 		for _, file := range files {
-			fileName := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
-			errMessage = fmt.Sprintf("%s\n%s", errMessage, fileName)
+			certificationNames = append(certificationNames, strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())))
+		}
+		errMessage := fmt.Sprintf("Error: `%s` does not exist", certificationPath)
+		// Only list the closest certifications if there are any. Otherwise, list all of them.
+		if closest := suggest.Closest(certification, certificationNames); len(closest) > 0 {
+			errMessage = fmt.Sprintf("%s\nDid you mean one of the following:\n%s", errMessage, strings.Join(closest, "\n"))
+		} else {
+			errMessage = fmt.Sprintf("%s\nUse one of the following:\n%s", errMessage, strings.Join(certificationNames, "\n"))
 		}
 		errMessages = append(errMessages, errors.New(errMessage))
 		return "", errMessages
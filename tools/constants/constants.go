@@ -11,6 +11,8 @@ const (
 	DefaultCertificationsFolder = "certifications"
 	// DefaultComponentsFolder is the folder where to store components.
 	DefaultComponentsFolder = "components"
+	// DefaultMappingsFolder is the folder where to store the mappings between the controls of standards.
+	DefaultMappingsFolder = "mappings"
 	// DefaultDestination is the root folder where to store standards, certifications, and components.
 	DefaultDestination = "opencontrols"
 	// DefaultConfigYaml is the file name for the file to find config details
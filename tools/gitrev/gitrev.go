@@ -0,0 +1,80 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package gitrev
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Checkout checks out a revision of the git repository containing dir into a temporary worktree.
+// It returns the path of dir within the worktree and a function that removes the worktree.
+func Checkout(dir string, revision string) (string, func(), error) {
+	topLevel, relativeDir, err := locate(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	tempDir, err := ioutil.TempDir("", "masonry-worktree")
+	if err != nil {
+		return "", nil, err
+	}
+	worktree := filepath.Join(tempDir, "worktree")
+	if _, err := git(topLevel, "worktree", "add", "--detach", worktree, revision); err != nil {
+		os.RemoveAll(tempDir)
+		return "", nil, fmt.Errorf("Unable to check out the revision %s: %v", revision, err)
+	}
+	cleanup := func() {
+		git(topLevel, "worktree", "remove", "--force", worktree)
+		os.RemoveAll(tempDir)
+	}
+	return filepath.Join(worktree, relativeDir), cleanup, nil
+}
+
+// Describe returns the abbreviated commit of a revision.
+func Describe(dir string, revision string) (string, error) {
+	return git(dir, "rev-parse", "--short", revision+"^{commit}")
+}
+
+// locate returns the top level directory of the git repository containing dir and the path of dir within it.
+func locate(dir string) (string, string, error) {
+	absoluteDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+	if absoluteDir, err = filepath.EvalSymlinks(absoluteDir); err != nil {
+		return "", "", err
+	}
+	topLevel, err := git(absoluteDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", "", fmt.Errorf("%s is not in a git repository: %v", dir, err)
+	}
+	if topLevel, err = filepath.EvalSymlinks(topLevel); err != nil {
+		return "", "", err
+	}
+	relativeDir, err := filepath.Rel(topLevel, absoluteDir)
+	if err != nil {
+		return "", "", err
+	}
+	return topLevel, relativeDir, nil
+}
+
+// git runs a git command in dir and returns its trimmed output.
+func git(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%s", message)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,58 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package gitrev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commit writes the file in the repository and commits it.
+func commit(t *testing.T, repo string, file string, content string) {
+	path := filepath.Join(repo, file)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	_, err := git(repo, "add", "-A")
+	require.NoError(t, err)
+	_, err = git(repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", file)
+	require.NoError(t, err)
+}
+
+func TestCheckout(t *testing.T) {
+	repo, err := ioutil.TempDir("", "gitrev")
+	require.NoError(t, err)
+	defer os.RemoveAll(repo)
+	_, err = git(repo, "init", "-q")
+	require.NoError(t, err)
+	commit(t, repo, filepath.Join("opencontrols", "file.txt"), "old")
+	_, err = git(repo, "tag", "v1")
+	require.NoError(t, err)
+	commit(t, repo, filepath.Join("opencontrols", "file.txt"), "new")
+
+	// Check that the directory is checked out at the revision
+	dir, cleanup, err := Checkout(filepath.Join(repo, "opencontrols"), "v1")
+	require.NoError(t, err)
+	content, err := ioutil.ReadFile(filepath.Join(dir, "file.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+	// Check that the worktree is removed
+	cleanup()
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+
+	// Check that unknown revisions are reported
+	_, _, err = Checkout(repo, "v2")
+	assert.Error(t, err)
+
+	// Check that revisions are described by their commit
+	description, err := Describe(repo, "v1")
+	assert.NoError(t, err)
+	assert.Len(t, description, 7)
+}
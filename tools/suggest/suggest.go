@@ -0,0 +1,137 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package suggest
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fvbommel/sortorder"
+)
+
+// maxSuggestions is the maximum number of candidates returned by Closest.
+const maxSuggestions = 3
+
+var (
+	// enhancementPattern matches the enhancement syntax of a control such as the " (1)" in "AC-2 (1)".
+	enhancementPattern = regexp.MustCompile(`\s*\((\w+)\)`)
+	// leadingZerosPattern matches the leading zeros of a number such as the "0" in "AC-02".
+	leadingZerosPattern = regexp.MustCompile(`(^|[^0-9])0+([0-9])`)
+	// separatorsPattern matches everything that is not meaningful when comparing keys.
+	separatorsPattern = regexp.MustCompile(`[^a-z0-9.]`)
+)
+
+// Normalize converts a key into a form where insignificant differences are removed.
+// The case, zero padding, separators and the enhancement syntax are ignored, so that "ac-02(1)", "AC-2 (1)" and
+// "AC-2.1" all normalize to "ac2.1".
+func Normalize(key string) string {
+	normalized := strings.ToLower(strings.TrimSpace(key))
+	normalized = enhancementPattern.ReplaceAllString(normalized, ".$1")
+	normalized = leadingZerosPattern.ReplaceAllString(normalized, "$1$2")
+	return separatorsPattern.ReplaceAllString(normalized, "")
+}
+
+// distance computes the Levenshtein edit distance between two strings.
+func distance(a string, b string) int {
+	ar, br := []rune(a), []rune(b)
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(br)]
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
+
+type candidate struct {
+	key      string
+	distance int
+}
+
+// Closest returns the candidates that are the closest to the given key, best match first.
+// Candidates that are equal to the key once normalized are always returned. Otherwise, candidates are only
+// returned when they are within a third of the key length in edit distance (with a minimum of 2 edits).
+func Closest(key string, candidates []string) []string {
+	normalizedKey := Normalize(key)
+	threshold := len(normalizedKey) / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+	var matches []candidate
+	for _, c := range candidates {
+		if c == key {
+			continue
+		}
+		d := distance(normalizedKey, Normalize(c))
+		if d <= threshold {
+			matches = append(matches, candidate{key: c, distance: d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return sortorder.NaturalLess(matches[i].key, matches[j].key)
+	})
+	// An exact match after normalization is a much better suggestion than anything else.
+	if len(matches) > 0 && matches[0].distance == 0 {
+		exact := 1
+		for exact < len(matches) && matches[exact].distance == 0 {
+			exact++
+		}
+		matches = matches[:exact]
+	}
+	if len(matches) > maxSuggestions {
+		matches = matches[:maxSuggestions]
+	}
+	result := make([]string, len(matches))
+	for idx, match := range matches {
+		result[idx] = match.key
+	}
+	return result
+}
+
+// DidYouMean returns a sentence suggesting the candidates closest to the given key
+// (e.g. "Did you mean AC-2 or AC-20?"). An empty string is returned when there is no close candidate.
+func DidYouMean(key string, candidates []string) string {
+	closest := Closest(key, candidates)
+	switch len(closest) {
+	case 0:
+		return ""
+	case 1:
+		return "Did you mean " + closest[0] + "?"
+	default:
+		return "Did you mean " + strings.Join(closest[:len(closest)-1], ", ") + " or " + closest[len(closest)-1] + "?"
+	}
+}
+
+// Append adds the DidYouMean sentence for the key to the end of the message when there are close candidates.
+func Append(message string, key string, candidates []string) string {
+	if didYouMean := DidYouMean(key, candidates); didYouMean != "" {
+		return message + " " + didYouMean
+	}
+	return message
+}
@@ -0,0 +1,75 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package suggest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type normalizeTest struct {
+	key      string
+	expected string
+}
+
+var normalizeTests = []normalizeTest{
+	// Check that the case is ignored
+	{"ac-2", "ac2"},
+	// Check that zero padding is ignored
+	{"AC-02", "ac2"},
+	// Check that the enhancement syntax with a space is normalized
+	{"AC-2 (1)", "ac2.1"},
+	// Check that the enhancement syntax without a space is normalized
+	{"AC-2(1)", "ac2.1"},
+	// Check that the dotted enhancement syntax is normalized
+	{"ac-2.1", "ac2.1"},
+	// Check that numbers with zeros that are not padding are kept
+	{"AC-10", "ac10"},
+	// Check that dotted keys are kept
+	{"1.1.1", "1.1.1"},
+}
+
+func TestNormalize(t *testing.T) {
+	for _, example := range normalizeTests {
+		// Check that the key is normalized as expected
+		assert.Equal(t, example.expected, Normalize(example.key))
+	}
+}
+
+type closestTest struct {
+	key        string
+	candidates []string
+	expected   []string
+}
+
+var closestTests = []closestTest{
+	// Check that a zero padded key suggests the key without padding only
+	{"AC-02", []string{"AC-1", "AC-2", "AC-20", "AC-2 (1)"}, []string{"AC-2"}},
+	// Check that the enhancement syntax suggests the right enhancement
+	{"AC-2(1)", []string{"AC-2", "AC-2 (1)", "AC-2 (2)"}, []string{"AC-2 (1)"}},
+	// Check that typos suggest the closest candidates
+	{"LAT", []string{"LATO", "FedRAMP-high", "FedRAMP-moderate"}, []string{"LATO"}},
+	// Check that no candidate is suggested when nothing is close
+	{"ISO-27001", []string{"LATO", "FedRAMP-high"}, nil},
+	// Check that the number of suggestions is limited
+	{"AC-9", []string{"AC-1", "AC-2", "AC-3", "AC-4"}, []string{"AC-1", "AC-2", "AC-3"}},
+}
+
+func TestClosest(t *testing.T) {
+	for _, example := range closestTests {
+		// Check that the closest candidates are returned
+		assert.Equal(t, len(example.expected), len(Closest(example.key, example.candidates)))
+		if len(example.expected) > 0 {
+			assert.Equal(t, example.expected, Closest(example.key, example.candidates))
+		}
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	assert.Equal(t, "Did you mean AC-2?", DidYouMean("AC-02", []string{"AC-2", "CM-2"}))
+	assert.Equal(t, "Did you mean AC-1, AC-2 or AC-3?", DidYouMean("AC-9", []string{"AC-1", "AC-2", "AC-3"}))
+	assert.Equal(t, "", DidYouMean("ISO-27001", []string{"LATO"}))
+}
@@ -0,0 +1,135 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines printed around each change.
+const contextLines = 3
+
+// operation is a line of the edit script between two texts.
+type operation struct {
+	kind byte
+	line string
+	// oldLine and newLine are the indexes of the line in the old and new texts.
+	oldLine int
+	newLine int
+}
+
+// Lines splits the text into lines, ignoring the final new line.
+func Lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Unified returns the differences between the old and new lines in the unified diff format.
+// An empty string is returned when the lines are identical.
+func Unified(oldName string, newName string, oldLines []string, newLines []string) string {
+	operations := diff(oldLines, newLines)
+	var hunks []string
+	for start := 0; start < len(operations); {
+		// Look for the next change.
+		for start < len(operations) && operations[start].kind == ' ' {
+			start++
+		}
+		if start == len(operations) {
+			break
+		}
+		// Extend the hunk until there are enough unchanged lines after the last change.
+		end, unchanged := start, 0
+		for end < len(operations) && unchanged <= 2*contextLines {
+			if operations[end].kind == ' ' {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+			end++
+		}
+		if unchanged > contextLines {
+			end -= unchanged - contextLines
+		}
+		first := start - contextLines
+		if first < 0 {
+			first = 0
+		}
+		hunks = append(hunks, hunk(operations[first:end]))
+		start = end
+	}
+	if len(hunks) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("--- %s\n+++ %s\n%s", oldName, newName, strings.Join(hunks, ""))
+}
+
+// hunk formats a list of operations with its header.
+func hunk(operations []operation) string {
+	var oldCount, newCount int
+	var body strings.Builder
+	for _, op := range operations {
+		switch op.kind {
+		case '-':
+			oldCount++
+		case '+':
+			newCount++
+		default:
+			oldCount++
+			newCount++
+		}
+		body.WriteString(string(op.kind) + op.line + "\n")
+	}
+	return fmt.Sprintf("@@ -%s +%s @@\n%s", hunkRange(operations[0].oldLine, oldCount),
+		hunkRange(operations[0].newLine, newCount), body.String())
+}
+
+func hunkRange(start int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// diff computes the edit script between the old and new lines using their longest common subsequence.
+func diff(oldLines []string, newLines []string) []operation {
+	lengths := make([][]int, len(oldLines)+1)
+	for idx := range lengths {
+		lengths[idx] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	var operations []operation
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			operations = append(operations, operation{' ', oldLines[i], i, j})
+			i++
+			j++
+		case j == len(newLines) || i < len(oldLines) && lengths[i+1][j] >= lengths[i][j+1]:
+			operations = append(operations, operation{'-', oldLines[i], i, j})
+			i++
+		default:
+			operations = append(operations, operation{'+', newLines[j], i, j})
+			j++
+		}
+	}
+	return operations
+}
@@ -0,0 +1,43 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package textdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type unifiedTest struct {
+	oldText  string
+	newText  string
+	expected string
+}
+
+var unifiedTests = []unifiedTest{
+	// Check that identical texts have no differences
+	{"a\nb\n", "a\nb\n", ""},
+	// Check that a changed line is reported with its context
+	{
+		"1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+		"1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+		"--- old\n+++ new\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+	},
+	// Check that distant changes are reported in separate hunks
+	{
+		"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+		"one\n2\n3\n4\n5\n6\n7\n8\n9\n",
+		"--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -7,4 +7,3 @@\n 7\n 8\n 9\n-10\n",
+	},
+	// Check that additions to an empty text are reported
+	{"", "a\n", "--- old\n+++ new\n@@ -0,0 +1 @@\n+a\n"},
+}
+
+func TestUnified(t *testing.T) {
+	for _, example := range unifiedTests {
+		actual := Unified("old", "new", Lines(example.oldText), Lines(example.newText))
+		assert.Equal(t, example.expected, actual)
+	}
+}
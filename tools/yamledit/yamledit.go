@@ -0,0 +1,438 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package yamledit
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Document is a YAML document that can be edited line by line while keeping its comments, key order and
+// formatting. Only the block structure of the document (mappings and sequences) is parsed. This is sufficient for
+// the OpenControl YAML files (component.yaml, standards and certifications).
+type Document struct {
+	lines []string
+	root  []*Node
+}
+
+// Node is a mapping entry or a sequence item of a Document along with the lines it spans.
+type Node struct {
+	// Key is the key of the mapping entry. It is empty for sequence items.
+	Key string
+	// Value is the unquoted value when the node is a single line scalar.
+	Value string
+	// RawValue is the value as it is written in the document.
+	RawValue string
+	// Children are the entries of a nested mapping or the items of a nested sequence.
+	Children []*Node
+	// IsItem is true when the node is a sequence item.
+	IsItem bool
+	// Column is the column of the key or the dash of the sequence item.
+	Column int
+	// Line is the first line of the node (starting at 0).
+	Line int
+	// End is the line after the last line of the node.
+	End int
+
+	valueStart int
+	valueEnd   int
+}
+
+// ErrMultiLineValue is returned when trying to set the value of a node that does not have a single line scalar.
+var ErrMultiLineValue = errors.New("the value of the node is not a single line scalar")
+
+var (
+	// keyPattern matches the key of a mapping entry and the separator that follows it.
+	keyPattern = regexp.MustCompile(`^("(?:[^"\\]|\\.)*"|'(?:[^']|'')*'|[^\s#'"\-\[\]{}][^#]*?|-[^\s#][^#]*?)\s*:(?:\s+|$)`)
+	// plainPattern matches the values that can be written without quotes.
+	plainPattern = regexp.MustCompile(`^[A-Za-z0-9_./()][A-Za-z0-9 _./()-]*$`)
+	// reservedPattern matches the plain values that YAML would not parse as strings.
+	reservedPattern = regexp.MustCompile(`^(?i:true|false|yes|no|on|off|null|~|[-+]?[0-9]+|[-+]?[0-9]*\.[0-9]+)$`)
+)
+
+// Parse parses the block structure of the YAML data.
+func Parse(data []byte) (*Document, error) {
+	text := strings.Replace(string(data), "\r\n", "\n", -1)
+	lines := strings.Split(text, "\n")
+	// Avoid an extra empty line for the final new line.
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	d := &Document{lines: lines}
+	if err := d.parse(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Bytes returns the content of the document.
+func (d *Document) Bytes() []byte {
+	if len(d.lines) == 0 {
+		return []byte{}
+	}
+	return []byte(strings.Join(d.lines, "\n") + "\n")
+}
+
+// Lines returns the lines of the document.
+func (d *Document) Lines() []string {
+	return append([]string{}, d.lines...)
+}
+
+// Root returns the top level nodes of the document.
+func (d *Document) Root() []*Node {
+	return d.root
+}
+
+// Get returns the top level mapping entry with the given key.
+func (d *Document) Get(key string) *Node {
+	return find(d.root, key)
+}
+
+// Get returns the entry with the given key of the nested mapping.
+func (n *Node) Get(key string) *Node {
+	if n == nil {
+		return nil
+	}
+	return find(n.Children, key)
+}
+
+// IsScalar returns true when the node has a single line scalar value.
+func (n *Node) IsScalar() bool {
+	return n != nil && n.valueEnd > n.valueStart && n.End == n.Line+1 && !isBlockIndicator(n.RawValue) &&
+		!strings.HasPrefix(n.RawValue, "[") && !strings.HasPrefix(n.RawValue, "{")
+}
+
+// Text returns the lines of the node with the indentation of the node removed.
+// The text of a sequence item starts with its dash.
+func (d *Document) Text(n *Node) string {
+	var text []string
+	for idx := n.Line; idx < n.End; idx++ {
+		line := d.lines[idx]
+		if len(line) >= n.Column && strings.TrimSpace(line[:n.Column]) == "" {
+			line = line[n.Column:]
+		} else {
+			line = strings.TrimLeft(line, " ")
+		}
+		text = append(text, line)
+	}
+	return strings.Join(text, "\n") + "\n"
+}
+
+// Unmarshal decodes the content of the node into out.
+// For a mapping entry, out receives the value of the entry. For a sequence item, out receives the item.
+func (d *Document) Unmarshal(n *Node, out interface{}) error {
+	text := d.Text(n)
+	if n.IsItem {
+		// Replace the dash with a space to keep the alignment of the item content.
+		text = " " + text[1:]
+		return yaml.Unmarshal([]byte(text), out)
+	}
+	var entry yaml.MapSlice
+	if err := yaml.Unmarshal([]byte(text), &entry); err != nil {
+		return err
+	}
+	if len(entry) != 1 {
+		return errors.New("unable to decode the node")
+	}
+	data, err := yaml.Marshal(entry[0].Value)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, out)
+}
+
+// SetValue replaces the single line scalar value of the node with the given value.
+// The quoting style of the existing value is kept when possible.
+func (d *Document) SetValue(n *Node, value string) error {
+	if !n.IsScalar() {
+		return ErrMultiLineValue
+	}
+	line := d.lines[n.Line]
+	lines := d.Lines()
+	lines[n.Line] = line[:n.valueStart] + formatLike(n.RawValue, value) + line[n.valueEnd:]
+	return d.update(lines)
+}
+
+// Remove deletes the lines of the node along with the comments right above it.
+func (d *Document) Remove(n *Node) error {
+	if n.IsItem || strings.TrimSpace(d.lines[n.Line][:n.Column]) == "" {
+		start := n.Line
+		for start > 0 && indentOf(d.lines[start-1]) == n.Column &&
+			strings.HasPrefix(strings.TrimSpace(d.lines[start-1]), "#") {
+			start--
+		}
+		return d.update(append(d.Lines()[:start], d.lines[n.End:]...))
+	}
+	// The node is the first entry of a mapping inside a sequence item (e.g. "- key: value").
+	// Move the next line of the item onto the dash line or drop the item if this is the only entry.
+	dash := d.lines[n.Line][:n.Column]
+	rest := d.Lines()[n.End:]
+	lines := d.Lines()[:n.Line]
+	if len(rest) > 0 && indentOf(rest[0]) == n.Column {
+		lines = append(lines, dash+rest[0][n.Column:])
+		rest = rest[1:]
+	}
+	return d.update(append(lines, rest...))
+}
+
+// Replace replaces the lines of the node with the given lines. The lines are indented at the column of the node,
+// the first one keeps the dash of the sequence item the node may start.
+func (d *Document) Replace(n *Node, lines ...string) error {
+	replaced := make([]string, len(lines))
+	for idx, line := range lines {
+		replaced[idx] = strings.Repeat(" ", n.Column) + line
+	}
+	if len(lines) > 0 {
+		replaced[0] = d.lines[n.Line][:n.Column] + lines[0]
+	}
+	return d.update(append(append(d.Lines()[:n.Line], replaced...), d.lines[n.End:]...))
+}
+
+// InsertAfter adds the given lines after the node. The lines are indented at the column of the node.
+func (d *Document) InsertAfter(n *Node, lines ...string) error {
+	return d.InsertAt(n.End, n.Column, lines...)
+}
+
+// InsertAt adds the given lines before the line at the given index. The lines are indented at the given column.
+func (d *Document) InsertAt(index int, column int, lines ...string) error {
+	indented := make([]string, len(lines))
+	for idx, line := range lines {
+		indented[idx] = strings.Repeat(" ", column) + line
+	}
+	return d.update(append(append(d.Lines()[:index], indented...), d.lines[index:]...))
+}
+
+// update replaces the lines of the document. The document is left unchanged if the new lines cannot be parsed.
+func (d *Document) update(lines []string) error {
+	previous := d.lines
+	d.lines = lines
+	if err := d.parse(); err != nil {
+		d.lines = previous
+		_ = d.parse()
+		return err
+	}
+	return nil
+}
+
+// Quote formats the value so that it is parsed back as the same string.
+func Quote(value string) string {
+	if plainPattern.MatchString(value) && !reservedPattern.MatchString(value) && strings.TrimSpace(value) == value {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+// formatLike formats the value with the same quoting style as the raw value.
+func formatLike(rawValue string, value string) string {
+	switch {
+	case strings.HasPrefix(rawValue, `"`):
+		return strconv.Quote(value)
+	case strings.HasPrefix(rawValue, `'`):
+		return "'" + strings.Replace(value, "'", "''", -1) + "'"
+	default:
+		return Quote(value)
+	}
+}
+
+// unquote returns the string represented by the raw scalar value.
+func unquote(rawValue string) string {
+	switch {
+	case strings.HasPrefix(rawValue, `"`):
+		if value, err := strconv.Unquote(rawValue); err == nil {
+			return value
+		}
+		return strings.Trim(rawValue, `"`)
+	case strings.HasPrefix(rawValue, `'`):
+		return strings.Replace(strings.TrimSuffix(strings.TrimPrefix(rawValue, "'"), "'"), "''", "'", -1)
+	default:
+		return rawValue
+	}
+}
+
+func find(nodes []*Node, key string) *Node {
+	for _, node := range nodes {
+		if !node.IsItem && node.Key == key {
+			return node
+		}
+	}
+	return nil
+}
+
+func isBlockIndicator(rawValue string) bool {
+	return strings.HasPrefix(rawValue, "|") || strings.HasPrefix(rawValue, ">")
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isSignificant(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" && !strings.HasPrefix(trimmed, "#") && trimmed != "---"
+}
+
+// startsItem returns true if the text starts with a sequence item dash.
+func startsItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// valueEnd returns the position of the end of the value, ignoring a trailing comment.
+func valueEnd(text string) int {
+	inSingle, inDouble := false, false
+	for idx := 0; idx < len(text); idx++ {
+		switch text[idx] {
+		case '\\':
+			if inDouble {
+				idx++
+			}
+		case '"':
+			if !inSingle {
+				inDouble = !inDouble
+			}
+		case '\'':
+			if !inDouble {
+				inSingle = !inSingle
+			}
+		case '#':
+			if !inSingle && !inDouble && (idx == 0 || text[idx-1] == ' ' || text[idx-1] == '\t') {
+				return len(strings.TrimRight(text[:idx], " \t"))
+			}
+		}
+	}
+	return len(strings.TrimRight(text, " \t"))
+}
+
+func (d *Document) parse() error {
+	d.root = nil
+	line := d.nextSignificant(0)
+	if line >= len(d.lines) {
+		return nil
+	}
+	nodes, next, err := d.parseBlock(line, indentOf(d.lines[line]))
+	if err != nil {
+		return err
+	}
+	if next = d.nextSignificant(next); next < len(d.lines) {
+		return errors.New("unexpected content on line " + strconv.Itoa(next+1))
+	}
+	d.root = nodes
+	return nil
+}
+
+func (d *Document) nextSignificant(line int) int {
+	for line < len(d.lines) && !isSignificant(d.lines[line]) {
+		line++
+	}
+	return line
+}
+
+// lastSignificantEnd returns the line after the last significant line before end.
+func (d *Document) lastSignificantEnd(start int, end int) int {
+	for end > start+1 && !isSignificant(d.lines[end-1]) {
+		end--
+	}
+	return end
+}
+
+// parseBlock parses the nodes of a mapping or a sequence starting at the given line and column.
+func (d *Document) parseBlock(line int, column int) ([]*Node, int, error) {
+	var nodes []*Node
+	isSequence := startsItem(d.lines[line][column:])
+	next := line
+	for {
+		node, err := d.parseNode(line, column, isSequence)
+		if err != nil {
+			return nil, line, err
+		}
+		nodes = append(nodes, node)
+		next = node.End
+		line = d.nextSignificant(node.End)
+		if line >= len(d.lines) || indentOf(d.lines[line]) != column ||
+			startsItem(d.lines[line][column:]) != isSequence {
+			break
+		}
+	}
+	return nodes, next, nil
+}
+
+// consumeContinuation returns the line after the lines that are more indented than the column.
+func (d *Document) consumeContinuation(line int, column int) int {
+	end := line + 1
+	for end < len(d.lines) && (!isSignificant(d.lines[end]) || indentOf(d.lines[end]) > column) {
+		end++
+	}
+	return d.lastSignificantEnd(line, end)
+}
+
+func (d *Document) parseNode(line int, column int, isItem bool) (*Node, error) {
+	text := d.lines[line][column:]
+	node := &Node{Column: column, Line: line, IsItem: isItem}
+	if isItem {
+		content := strings.TrimLeft(text[1:], " ")
+		contentColumn := column + len(text) - len(content)
+		switch {
+		case valueEnd(content) == 0:
+			// The item content is on the following lines.
+			next := d.nextSignificant(line + 1)
+			node.End = line + 1
+			if next < len(d.lines) && indentOf(d.lines[next]) > column {
+				children, end, err := d.parseBlock(next, indentOf(d.lines[next]))
+				if err != nil {
+					return nil, err
+				}
+				node.Children, node.End = children, end
+			}
+		case startsItem(content) || keyPattern.MatchString(content) && !strings.HasPrefix(content, "[") && !strings.HasPrefix(content, "{"):
+			// The item is a mapping or a sequence that starts on the same line.
+			children, end, err := d.parseBlock(line, contentColumn)
+			if err != nil {
+				return nil, err
+			}
+			node.Children, node.End = children, end
+		default:
+			d.setValue(node, line, contentColumn)
+			node.End = d.consumeContinuation(line, column)
+		}
+		return node, nil
+	}
+	match := keyPattern.FindStringSubmatchIndex(text)
+	if match == nil {
+		return nil, errors.New("unable to parse line " + strconv.Itoa(line+1))
+	}
+	node.Key = unquote(strings.TrimSpace(text[match[2]:match[3]]))
+	valueColumn := column + match[1]
+	if valueEnd(d.lines[line][valueColumn:]) > 0 {
+		d.setValue(node, line, valueColumn)
+		node.End = d.consumeContinuation(line, column)
+		return node, nil
+	}
+	node.End = line + 1
+	next := d.nextSignificant(line + 1)
+	if next >= len(d.lines) {
+		return node, nil
+	}
+	nextIndent := indentOf(d.lines[next])
+	// Sequences can be at the same indentation as their key.
+	if nextIndent > column || nextIndent == column && startsItem(d.lines[next][column:]) {
+		children, end, err := d.parseBlock(next, nextIndent)
+		if err != nil {
+			return nil, err
+		}
+		node.Children, node.End = children, end
+	}
+	return node, nil
+}
+
+func (d *Document) setValue(node *Node, line int, column int) {
+	text := d.lines[line][column:]
+	end := valueEnd(text)
+	node.valueStart, node.valueEnd = column, column+end
+	node.RawValue = text[:end]
+	node.Value = unquote(node.RawValue)
+}
@@ -0,0 +1,158 @@
+/*
+ Copyright (C) 2018 OpenControl Contributors. See LICENSE.md for license.
+*/
+
+package yamledit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const component = `# Component maintained by the platform team
+name: Amazon Elastic Compute Cloud
+key: EC2
+schema_version: 3.1.0
+satisfies:
+- control_key: ac-02 # typo in the key
+  standard_key: "NIST-800-53"
+  covered_by:
+  - verification_key: EC2_Verification_1
+  implementation_status: Partial
+  implementation_statuses:
+    - "partial"
+  narrative:
+    - key: "a"
+      text: |
+        Justification in narrative form A
+
+        with a blank line
+- control_key: CM-2
+  standard_key: NIST-800-53
+
+  # Second item
+  narrative: [{text: "flow"}]
+`
+
+func TestParse(t *testing.T) {
+	doc, err := Parse([]byte(component))
+	require.NoError(t, err)
+	assert.Len(t, doc.Root(), 4)
+	assert.Equal(t, "EC2", doc.Get("key").Value)
+	assert.Equal(t, "3.1.0", doc.Get("schema_version").Value)
+
+	satisfies := doc.Get("satisfies")
+	require.NotNil(t, satisfies)
+	require.Len(t, satisfies.Children, 2)
+	first := satisfies.Children[0]
+	assert.True(t, first.IsItem)
+	assert.Equal(t, 5, first.Line)
+	assert.Equal(t, 18, first.End)
+	assert.Equal(t, "ac-02", first.Get("control_key").Value)
+	assert.Equal(t, "NIST-800-53", first.Get("standard_key").Value)
+	assert.Equal(t, `"NIST-800-53"`, first.Get("standard_key").RawValue)
+	assert.Equal(t, "partial", first.Get("implementation_statuses").Children[0].Value)
+	narrative := first.Get("narrative").Children[0]
+	assert.Equal(t, "a", narrative.Get("key").Value)
+	assert.False(t, narrative.Get("text").IsScalar())
+
+	second := satisfies.Children[1]
+	assert.Equal(t, 18, second.Line)
+	assert.Equal(t, 23, second.End)
+	assert.False(t, second.Get("narrative").IsScalar())
+}
+
+func TestUnmarshal(t *testing.T) {
+	doc, err := Parse([]byte(component))
+	require.NoError(t, err)
+	var item struct {
+		ControlKey string `yaml:"control_key"`
+		Narrative  []struct {
+			Text string `yaml:"text"`
+		} `yaml:"narrative"`
+	}
+	require.NoError(t, doc.Unmarshal(doc.Get("satisfies").Children[0], &item))
+	assert.Equal(t, "ac-02", item.ControlKey)
+	assert.Equal(t, "Justification in narrative form A\n\nwith a blank line\n", item.Narrative[0].Text)
+
+	var statuses []string
+	require.NoError(t, doc.Unmarshal(doc.Get("satisfies").Children[0].Get("implementation_statuses"), &statuses))
+	assert.Equal(t, []string{"partial"}, statuses)
+}
+
+func TestSetValue(t *testing.T) {
+	doc, err := Parse([]byte(component))
+	require.NoError(t, err)
+	first := doc.Get("satisfies").Children[0]
+	require.NoError(t, doc.SetValue(first.Get("control_key"), "AC-2"))
+	first = doc.Get("satisfies").Children[0]
+	require.NoError(t, doc.SetValue(first.Get("standard_key"), "NIST-800-53 rev4"))
+	lines := doc.Lines()
+	// The trailing comment is kept.
+	assert.Equal(t, "- control_key: AC-2 # typo in the key", lines[5])
+	// The quoting style is kept.
+	assert.Equal(t, `  standard_key: "NIST-800-53 rev4"`, lines[6])
+	assert.Equal(t, ErrMultiLineValue, doc.SetValue(doc.Get("satisfies"), "value"))
+}
+
+func TestRemove(t *testing.T) {
+	doc, err := Parse([]byte(component))
+	require.NoError(t, err)
+	require.NoError(t, doc.Remove(doc.Get("satisfies").Children[0].Get("implementation_status")))
+	assert.Nil(t, doc.Get("satisfies").Children[0].Get("implementation_status"))
+	assert.Equal(t, "  implementation_statuses:", doc.Lines()[9])
+
+	// Removing the first entry of a sequence item moves the next entry onto the dash.
+	require.NoError(t, doc.Remove(doc.Get("satisfies").Children[1].Get("control_key")))
+	second := doc.Get("satisfies").Children[1]
+	assert.Equal(t, "- standard_key: NIST-800-53", doc.Lines()[second.Line])
+	assert.Nil(t, second.Get("control_key"))
+
+	require.NoError(t, doc.Remove(second))
+	assert.Len(t, doc.Get("satisfies").Children, 1)
+}
+
+func TestInsertAfter(t *testing.T) {
+	doc, err := Parse([]byte(component))
+	require.NoError(t, err)
+	statuses := doc.Get("satisfies").Children[0].Get("implementation_statuses")
+	require.NoError(t, doc.InsertAfter(statuses.Children[0], "- "+Quote("planned")))
+	statuses = doc.Get("satisfies").Children[0].Get("implementation_statuses")
+	require.Len(t, statuses.Children, 2)
+	assert.Equal(t, "planned", statuses.Children[1].Value)
+	assert.Equal(t, "    - planned", doc.Lines()[statuses.Children[1].Line])
+}
+
+func TestReplace(t *testing.T) {
+	doc, err := Parse([]byte(component))
+	require.NoError(t, err)
+	// The first entry of an item keeps the dash of the item.
+	controlKey := doc.Get("satisfies").Children[1].Get("control_key")
+	require.NoError(t, doc.Replace(controlKey, "control_key: CM-2 # fixed", "control_origins:", "  - shared"))
+	item := doc.Get("satisfies").Children[1]
+	assert.Equal(t, "- control_key: CM-2 # fixed", doc.Lines()[item.Line])
+	assert.Equal(t, "  control_origins:", doc.Lines()[item.Line+1])
+	assert.Equal(t, "shared", item.Get("control_origins").Children[0].Value)
+	assert.Equal(t, "NIST-800-53", item.Get("standard_key").Value)
+}
+
+func TestBytes(t *testing.T) {
+	doc, err := Parse([]byte(component))
+	require.NoError(t, err)
+	assert.Equal(t, component, string(doc.Bytes()))
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, "AC-2 (1)", Quote("AC-2 (1)"))
+	assert.Equal(t, `"1.1"`, Quote("1.1"))
+	assert.Equal(t, `"yes"`, Quote("yes"))
+	assert.Equal(t, `"a: b"`, Quote("a: b"))
+	assert.Equal(t, `"-a"`, Quote("-a"))
+}
+
+func TestParseError(t *testing.T) {
+	_, err := Parse([]byte("key: value\n  - nested\nnot a key\n"))
+	assert.Error(t, err)
+}
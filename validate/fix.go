@@ -0,0 +1,188 @@
+package validate
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/components"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/tools/constants"
+	"github.com/opencontrol/compliance-masonry/tools/textdiff"
+	"github.com/opencontrol/compliance-masonry/tools/yamledit"
+	"gopkg.in/yaml.v2"
+)
+
+// satisfyFix fixes the satisfies item at the given index of a component document.
+type satisfyFix func(workspace common.Workspace, doc *yamledit.Document, idx int) error
+
+// fixComponents rewrites the component files to fix the problems that can be fixed mechanically and prints the
+// differences. Problems that need a human decision (e.g. unknown statuses or conflicting duplicates) are left as is.
+func fixComponents(out io.Writer, workspace common.Workspace, openControlDir string) []string {
+	problems := make([]string, 0)
+	fileNames, _ := filepath.Glob(filepath.Join(openControlDir, constants.DefaultComponentsFolder, "*", "component.yaml"))
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		if err := fixComponentFile(out, workspace, fileName); err != nil {
+			problems = append(problems, fmt.Sprintf("Unable to fix %s: %v", fileName, err))
+		}
+	}
+	return problems
+}
+
+// fixComponentFile fixes a single component file and prints the differences.
+func fixComponentFile(out io.Writer, workspace common.Workspace, fileName string) error {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	doc, err := fixComponent(workspace, data)
+	if err != nil {
+		return err
+	}
+	diff := textdiff.Unified(fileName, fileName, textdiff.Lines(string(data)), doc.Lines())
+	if diff == "" {
+		return nil
+	}
+	fmt.Fprint(out, diff)
+	return ioutil.WriteFile(fileName, doc.Bytes(), info.Mode())
+}
+
+// fixComponent applies the fixes to the content of a component file.
+func fixComponent(workspace common.Workspace, data []byte) (*yamledit.Document, error) {
+	doc, err := yamledit.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	fixes := []satisfyFix{fixControlKey, fixStatusCasing}
+	base := components.Base{}
+	if err := yaml.Unmarshal(data, &base); err == nil && components.ComponentV3_1_0.EQ(base.SchemaVersion) {
+		fixes = append(fixes, foldImplementationStatus)
+	}
+	for idx := range satisfiesItems(doc) {
+		for _, fix := range fixes {
+			if err := fix(workspace, doc, idx); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return doc, removeIdenticalDuplicates(doc)
+}
+
+// satisfiesItems returns the items of the satisfies sequence of the document.
+func satisfiesItems(doc *yamledit.Document) []*yamledit.Node {
+	satisfies := doc.Get("satisfies")
+	if satisfies == nil {
+		return nil
+	}
+	return satisfies.Children
+}
+
+// fixControlKey replaces a control key with its spelling in the standard when they only differ in their format
+// (e.g. AC-02 instead of AC-2).
+func fixControlKey(workspace common.Workspace, doc *yamledit.Document, idx int) error {
+	item := satisfiesItems(doc)[idx]
+	controlKey, standardKey := item.Get("control_key"), item.Get("standard_key")
+	if !controlKey.IsScalar() || !standardKey.IsScalar() {
+		return nil
+	}
+	standard, found := workspace.GetStandard(standardKey.Value)
+	if !found {
+		return nil
+	}
+	key, found := controlkeys.NewIndex(standard).Find(controlKey.Value)
+	if !found || key == controlKey.Value {
+		return nil
+	}
+	return doc.SetValue(controlKey, key)
+}
+
+// fixStatusCasing lowercases the implementation statuses when the lowercase status is a known status.
+func fixStatusCasing(workspace common.Workspace, doc *yamledit.Document, idx int) error {
+	item := satisfiesItems(doc)[idx]
+	nodes := []*yamledit.Node{item.Get("implementation_status")}
+	if statuses := item.Get("implementation_statuses"); statuses != nil {
+		nodes = append(nodes, statuses.Children...)
+	}
+	for _, node := range nodes {
+		if !node.IsScalar() || validStatuses[node.Value] {
+			continue
+		}
+		status := strings.ToLower(strings.TrimSpace(node.Value))
+		if !validStatuses[status] {
+			continue
+		}
+		// Editing the value of a line does not move the other nodes.
+		if err := doc.SetValue(node, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// foldImplementationStatus moves implementation_status into implementation_statuses for the 3.1.0 components.
+func foldImplementationStatus(workspace common.Workspace, doc *yamledit.Document, idx int) error {
+	status := satisfiesItems(doc)[idx].Get("implementation_status")
+	if !status.IsScalar() {
+		return nil
+	}
+	statuses := satisfiesItems(doc)[idx].Get("implementation_statuses")
+	switch {
+	case statuses == nil:
+		if err := doc.InsertAfter(status, "implementation_statuses:", "  - "+status.RawValue); err != nil {
+			return err
+		}
+	case len(statuses.Children) > 0 && statuses.Children[0].IsItem:
+		found := false
+		for _, item := range statuses.Children {
+			found = found || item.Value == status.Value
+		}
+		if !found {
+			last := statuses.Children[len(statuses.Children)-1]
+			if err := doc.InsertAfter(last, "- "+status.RawValue); err != nil {
+				return err
+			}
+		}
+	default:
+		// Flow sequences are left to the user.
+		return nil
+	}
+	// Inserting after the status does not move it.
+	return doc.Remove(satisfiesItems(doc)[idx].Get("implementation_status"))
+}
+
+// removeIdenticalDuplicates removes the satisfies items that are identical to a previous item.
+// Duplicates with different content need a human decision and are kept.
+func removeIdenticalDuplicates(doc *yamledit.Document) error {
+	var seen []interface{}
+	for idx := 0; idx < len(satisfiesItems(doc)); {
+		item := satisfiesItems(doc)[idx]
+		var content interface{}
+		if err := doc.Unmarshal(item, &content); err != nil {
+			return err
+		}
+		duplicate := false
+		for _, previous := range seen {
+			duplicate = duplicate || reflect.DeepEqual(previous, content)
+		}
+		if !duplicate {
+			seen = append(seen, content)
+			idx++
+			continue
+		}
+		if err := doc.Remove(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package validate
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixComponent(t *testing.T) {
+	workspace, errs := lib.LoadData("../test/fixtures/opencontrol_fixtures_spellings/", "../test/fixtures/opencontrol_fixtures_spellings/certifications/LATO.yaml")
+	require.Empty(t, errs)
+	data, err := ioutil.ReadFile("../test/fixtures/validate_fixtures/component.yaml")
+	require.NoError(t, err)
+	expected, err := ioutil.ReadFile("../test/fixtures/validate_fixtures/component-fixed.yaml")
+	require.NoError(t, err)
+
+	doc, err := fixComponent(workspace, data)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(doc.Bytes()))
+
+	// Fixing a fixed component does not change it.
+	doc, err = fixComponent(workspace, expected)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(doc.Bytes()))
+}
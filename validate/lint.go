@@ -0,0 +1,226 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+)
+
+const (
+	// LintPlaceholder reports the narratives that match a placeholder pattern (e.g. TBD).
+	LintPlaceholder = "placeholder"
+	// LintMinLength reports the narratives that have fewer words than the minimum.
+	LintMinLength = "min-length"
+	// LintDuplicate reports the narratives that are nearly identical to the narrative of another component for the
+	// same control.
+	LintDuplicate = "duplicate"
+	// LintCopied reports the narratives that repeat the description of their control.
+	LintCopied = "copied"
+	// LintEmptyComplete reports the complete controls that do not have a narrative.
+	LintEmptyComplete = "empty-complete"
+	// LintAll enables all the lint rules.
+	LintAll = "all"
+)
+
+// lintRules are the names of the available lint rules.
+var lintRules = []string{LintPlaceholder, LintMinLength, LintDuplicate, LintCopied, LintEmptyComplete}
+
+// DefaultPlaceholders are the default patterns of the placeholder narratives.
+var DefaultPlaceholders = []string{`(?i)\bTBD\b`, `(?i)\bTODO\b`, `(?i)\bFIXME\b`, `(?i)\blorem ipsum\b`}
+
+// DefaultMinWords is the default minimum number of words of a narrative.
+const DefaultMinWords = 5
+
+// similarityThreshold is the ratio of shared word pairs above which two texts are considered nearly identical.
+const similarityThreshold = 0.8
+
+// LintConfig contains the settings of the opt-in narrative lint rules
+type LintConfig struct {
+	// Rules are the names of the enabled rules.
+	Rules []string
+	// Placeholders are the regular expressions matching placeholder narratives.
+	Placeholders []string
+	// MinWords is the minimum number of words of a narrative.
+	MinWords int
+}
+
+// linter applies the enabled lint rules.
+type linter struct {
+	rules        map[string]bool
+	placeholders []*regexp.Regexp
+	minWords     int
+}
+
+// newLinter checks the lint configuration and compiles the placeholder patterns.
+func newLinter(config LintConfig) (*linter, error) {
+	l := &linter{rules: make(map[string]bool), minWords: config.MinWords}
+	for _, rule := range config.Rules {
+		switch {
+		case rule == LintAll:
+			for _, rule := range lintRules {
+				l.rules[rule] = true
+			}
+		case contains(lintRules, rule):
+			l.rules[rule] = true
+		default:
+			return nil, fmt.Errorf("Unknown lint rule %s. Use one of the following: %s, %s", rule,
+				strings.Join(lintRules, ", "), LintAll)
+		}
+	}
+	for _, placeholder := range config.Placeholders {
+		pattern, err := regexp.Compile(placeholder)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid placeholder pattern %s: %v", placeholder, err)
+		}
+		l.placeholders = append(l.placeholders, pattern)
+	}
+	return l, nil
+}
+
+// lintedNarrative is a narrative that was already linted, kept to find near-duplicates.
+type lintedNarrative struct {
+	componentKey string
+	pairs        map[string]bool
+}
+
+// lint applies the enabled lint rules to the narratives of all the components.
+func (l *linter) lint(workspace common.Workspace) []string {
+	problems := make([]string, 0)
+	// Narratives indexed by standard and normalized control key.
+	linted := make(map[string][]lintedNarrative)
+	for _, component := range workspace.GetAllComponents() {
+		for _, satisfy := range component.GetAllSatisfies() {
+			prefix := fmt.Sprintf("Component %s: Satisfy '%s':", component.GetKey(), satisfy.GetControlKey())
+			if l.rules[LintEmptyComplete] && isComplete(satisfy) && !hasNarrative(satisfy) {
+				problems = append(problems, fmt.Sprintf("%s Control is complete but has no narrative.", prefix))
+			}
+			description := pairs(words(controlDescription(workspace, satisfy)))
+			controlID := satisfy.GetStandardKey() + "@" + controlkeys.Normalize(satisfy.GetStandardKey(), satisfy.GetControlKey())
+			for _, narrative := range satisfy.GetNarratives() {
+				text := strings.TrimSpace(narrative.GetText())
+				if text == "" {
+					continue
+				}
+				name := narrativeName(narrative)
+				if l.rules[LintPlaceholder] {
+					for _, placeholder := range l.placeholders {
+						if match := placeholder.FindString(text); match != "" {
+							problems = append(problems, fmt.Sprintf("%s %s contains the placeholder '%s'.", prefix, name, match))
+							break
+						}
+					}
+				}
+				textWords := words(text)
+				if l.rules[LintMinLength] && len(textWords) < l.minWords {
+					problems = append(problems, fmt.Sprintf("%s %s is too short: %d words, expected at least %d.", prefix, name, len(textWords), l.minWords))
+				}
+				textPairs := pairs(textWords)
+				// Very short narratives are reported by the minimum length rule instead.
+				if l.rules[LintCopied] && len(textWords) > 3 && containment(textPairs, description) >= similarityThreshold {
+					problems = append(problems, fmt.Sprintf("%s %s repeats the control description.", prefix, name))
+				}
+				if l.rules[LintDuplicate] {
+					for _, other := range linted[controlID] {
+						if other.componentKey != component.GetKey() && jaccard(textPairs, other.pairs) >= similarityThreshold {
+							problems = append(problems, fmt.Sprintf("%s %s is nearly identical to the narrative of component %s.", prefix, name, other.componentKey))
+							break
+						}
+					}
+					linted[controlID] = append(linted[controlID], lintedNarrative{component.GetKey(), textPairs})
+				}
+			}
+		}
+	}
+	return problems
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func narrativeName(narrative common.Section) string {
+	if narrative.GetKey() == "" {
+		return "Narrative"
+	}
+	return fmt.Sprintf("Narrative '%s'", narrative.GetKey())
+}
+
+func isComplete(satisfy common.Satisfies) bool {
+	return contains(implementationStatuses(satisfy), "complete")
+}
+
+func hasNarrative(satisfy common.Satisfies) bool {
+	for _, narrative := range satisfy.GetNarratives() {
+		if strings.TrimSpace(narrative.GetText()) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// controlDescription returns the description of the control satisfied, if it can be found in the workspace.
+func controlDescription(workspace common.Workspace, satisfy common.Satisfies) string {
+	standard, found := workspace.GetStandard(satisfy.GetStandardKey())
+	if !found {
+		return ""
+	}
+	control, found := controlkeys.NewIndex(standard).Get(satisfy.GetControlKey())
+	if !found {
+		return ""
+	}
+	return control.GetDescription()
+}
+
+var wordPattern = regexp.MustCompile(`[a-z0-9]+`)
+
+// words returns the lowercase words of the text.
+func words(text string) []string {
+	return wordPattern.FindAllString(strings.ToLower(text), -1)
+}
+
+// pairs returns the set of consecutive word pairs. Single words are kept as is.
+func pairs(words []string) map[string]bool {
+	set := make(map[string]bool)
+	if len(words) == 1 {
+		set[words[0]] = true
+	}
+	for idx := 1; idx < len(words); idx++ {
+		set[words[idx-1]+" "+words[idx]] = true
+	}
+	return set
+}
+
+// containment returns the ratio of the pairs of a that are in b.
+func containment(a map[string]bool, b map[string]bool) float64 {
+	if len(a) == 0 {
+		return 0
+	}
+	return float64(intersection(a, b)) / float64(len(a))
+}
+
+// jaccard returns the ratio of shared pairs between a and b.
+func jaccard(a map[string]bool, b map[string]bool) float64 {
+	shared := intersection(a, b)
+	if shared == 0 {
+		return 0
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func intersection(a map[string]bool, b map[string]bool) int {
+	shared := 0
+	for pair := range a {
+		if b[pair] {
+			shared++
+		}
+	}
+	return shared
+}
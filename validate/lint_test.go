@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lintTest struct {
+	rules    []string
+	expected []string
+}
+
+var lintTests = []lintTest{
+	// Check that the rules are opt-in
+	{nil, []string{}},
+	// Check that placeholders are reported
+	{[]string{LintPlaceholder}, []string{
+		"Component Web: Satisfy 'AC-2': Narrative 'b' contains the placeholder 'TBD'.",
+	}},
+	// Check that short narratives are reported
+	{[]string{LintMinLength}, []string{
+		"Component Web: Satisfy 'AC-2': Narrative 'b' is too short: 1 words, expected at least 5.",
+	}},
+	// Check that near-duplicates across components are reported, whatever the spelling of the control key
+	{[]string{LintDuplicate}, []string{
+		"Component Web: Satisfy 'AC-2': Narrative 'a' is nearly identical to the narrative of component Database.",
+	}},
+	// Check that narratives copied from the control description are reported
+	{[]string{LintCopied}, []string{
+		"Component Web: Satisfy 'CM-2': Narrative repeats the control description.",
+	}},
+	// Check that complete controls without narratives are reported
+	{[]string{LintEmptyComplete}, []string{
+		"Component Web: Satisfy 'AU-2': Control is complete but has no narrative.",
+	}},
+	// Check that all the rules can be enabled at once
+	{[]string{LintAll}, []string{
+		"Component Web: Satisfy 'AC-2': Narrative 'a' is nearly identical to the narrative of component Database.",
+		"Component Web: Satisfy 'AC-2': Narrative 'b' contains the placeholder 'TBD'.",
+		"Component Web: Satisfy 'AC-2': Narrative 'b' is too short: 1 words, expected at least 5.",
+		"Component Web: Satisfy 'AU-2': Control is complete but has no narrative.",
+		"Component Web: Satisfy 'CM-2': Narrative repeats the control description.",
+	}},
+}
+
+func TestLint(t *testing.T) {
+	workspace, errs := lib.LoadData("../test/fixtures/validate_fixtures/lint/", "../test/fixtures/validate_fixtures/lint/certifications/LATO.yaml")
+	require.Empty(t, errs)
+	for _, example := range lintTests {
+		linter, err := newLinter(LintConfig{Rules: example.rules, Placeholders: DefaultPlaceholders, MinWords: DefaultMinWords})
+		require.NoError(t, err)
+		assert.Equal(t, example.expected, linter.lint(workspace))
+	}
+}
+
+func TestNewLinter(t *testing.T) {
+	_, err := newLinter(LintConfig{Rules: []string{"spelling"}})
+	assert.EqualError(t, err, "Unknown lint rule spelling. Use one of the following: placeholder, min-length, duplicate, copied, empty-complete, all")
+	_, err = newLinter(LintConfig{Placeholders: []string{"("}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+)
+
+// validateParameters reports the parameters of the components that are missing, different from the value required
+// by the certification or outside of the values it allows.
+func validateParameters(workspace common.Workspace) []string {
+	problems := make([]string, 0)
+	certification := workspace.GetCertification()
+	if certification == nil {
+		return problems
+	}
+	for _, component := range workspace.GetAllComponents() {
+		for _, satisfy := range component.GetAllSatisfies() {
+			requirements := certification.GetControlRequirements(satisfy.GetStandardKey(), satisfy.GetControlKey())
+			if len(requirements.Parameters) == 0 {
+				continue
+			}
+			values := make(map[string]string)
+			for _, parameter := range satisfy.GetParameters() {
+				values[parameter.GetKey()] = parameter.GetText()
+			}
+			var parameterKeys []string
+			for parameterKey := range requirements.Parameters {
+				parameterKeys = append(parameterKeys, parameterKey)
+			}
+			sort.Sort(sortorder.Natural(parameterKeys))
+			prefix := fmt.Sprintf("Component %s: Satisfy '%s':", component.GetKey(), satisfy.GetControlKey())
+			for _, parameterKey := range parameterKeys {
+				assignment := requirements.Parameters[parameterKey]
+				value, found := values[parameterKey]
+				switch {
+				case !found:
+					problems = append(problems, fmt.Sprintf("%s Parameter '%s' is missing, certification %s requires %s.",
+						prefix, parameterKey, certification.GetKey(), assignment))
+				case !assignment.Accepts(value):
+					problems = append(problems, fmt.Sprintf("%s Parameter '%s' is '%s', certification %s requires %s.",
+						prefix, parameterKey, value, certification.GetKey(), assignment))
+				}
+			}
+		}
+	}
+	return problems
+}
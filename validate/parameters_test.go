@@ -0,0 +1,20 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateParameters(t *testing.T) {
+	workspace, errs := lib.LoadData("../test/fixtures/validate_fixtures/parameters/", "../test/fixtures/validate_fixtures/parameters/certifications/LATO.yaml")
+	require.Empty(t, errs)
+	// Check that different, disallowed and missing values are reported, whatever the spelling of the control key
+	assert.Equal(t, []string{
+		"Component Web: Satisfy 'AC-02': Parameter 'a' is '12 hours', certification LATO requires 24 hours.",
+		"Component Web: Satisfy 'AC-02': Parameter 'b' is 'daily', certification LATO requires one of monthly, weekly.",
+		"Component Web: Satisfy 'AC-02': Parameter 'c' is missing, certification LATO requires at least annually.",
+	}, validateParameters(workspace))
+}
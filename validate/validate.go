@@ -4,69 +4,186 @@ import (
 	"fmt"
 	"github.com/opencontrol/compliance-masonry/pkg/lib"
 	"github.com/opencontrol/compliance-masonry/pkg/lib/common"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/controlkeys"
+	"github.com/opencontrol/compliance-masonry/pkg/lib/parts"
+	"github.com/opencontrol/compliance-masonry/tools/suggest"
+	"io"
 	"os"
+	"strings"
 )
 
+const (
+	openControlDir    = "opencontrols/"
+	certificationPath = "opencontrols/certifications/fedramp-high.yaml"
+)
+
+// validStatuses are the values allowed for the implementation statuses.
+var validStatuses = map[string]bool{
+	"complete":       true,
+	"partial":        true,
+	"not applicable": true,
+	"planned":        true,
+	"unsatisfied":    true,
+	"unknown":        true,
+	"none":           true,
+}
+
+// Config contains the options of the validation
+type Config struct {
+	// Fix rewrites the component files to fix the problems that do not need a human decision.
+	Fix bool
+	// Lint contains the opt-in narrative lint rules.
+	Lint LintConfig
+}
+
 // Validate validates opencontrol masonry repository that has been previously obtained by masonry get
-func Validate() {
-	problems := make([]string, 0)
-	workspace, errors := lib.LoadData("opencontrols/", "opencontrols/certifications/fedramp-high.yaml")
+func Validate(out io.Writer, config Config) {
+	linter, err := newLinter(config.Lint)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		os.Exit(1)
+	}
+	workspace, errors := lib.LoadData(openControlDir, certificationPath)
 	if errors != nil {
-		fmt.Println(errors)
+		fmt.Fprintln(out, errors)
 		os.Exit(1)
 	}
+	problems := make([]string, 0)
+	if config.Fix {
+		problems = append(problems, fixComponents(out, workspace, openControlDir)...)
+		// Validate what remains after the fixes.
+		workspace, errors = lib.LoadData(openControlDir, certificationPath)
+		if errors != nil {
+			fmt.Fprintln(out, errors)
+			os.Exit(1)
+		}
+	}
+	problems = append(problems, validateCertification(workspace)...)
+	problems = append(problems, validateParameters(workspace)...)
+	problems = append(problems, validateWithdrawnControls(workspace)...)
+	problems = append(problems, validateInheritedControls(workspace)...)
 	for _, component := range workspace.GetAllComponents() {
 		problems = append(problems, validateComponent(workspace, component)...)
 	}
+	problems = append(problems, linter.lint(workspace)...)
 	for _, problem := range problems {
-		fmt.Println(problem)
+		fmt.Fprintln(out, problem)
 	}
 	os.Exit(len(problems))
 }
 
+func validateCertification(workspace common.Workspace) []string {
+	problems := make([]string, 0)
+	for _, err := range lib.CheckCertification(workspace) {
+		problems = append(problems, err.Error())
+	}
+	return problems
+}
+
+func validateWithdrawnControls(workspace common.Workspace) []string {
+	problems := make([]string, 0)
+	for _, err := range lib.CheckWithdrawnControls(workspace) {
+		problems = append(problems, err.Error())
+	}
+	return problems
+}
+
+func validateInheritedControls(workspace common.Workspace) []string {
+	problems := make([]string, 0)
+	for _, err := range lib.CheckInheritedControls(workspace) {
+		problems = append(problems, err.Error())
+	}
+	return problems
+}
+
 func validateComponent(workspace common.Workspace, component common.Component) []string {
 	problems := make([]string, 0)
 	uniq := make(map[string]map[string]common.Satisfies)
+	indexes := make(map[string]controlkeys.Index)
 
 	for _, satisfy := range component.GetAllSatisfies() {
 		standardKey := satisfy.GetStandardKey()
 		_, ok := uniq[standardKey]
 		if !ok {
-			_, found := workspace.GetStandard(standardKey)
+			standard, found := workspace.GetStandard(standardKey)
 			if !found {
-				problems = append(problems, fmt.Sprintf("Component %s references standard %s, however that cannot be found in the workspace.", component.GetName(), standardKey))
+				problem := fmt.Sprintf("Component %s references standard %s, however that cannot be found in the workspace.", component.GetName(), standardKey)
+				problems = append(problems, suggest.Append(problem, standardKey, lib.GetStandardKeys(workspace)))
 				continue
 			}
 			uniq[standardKey] = make(map[string]common.Satisfies)
+			indexes[standardKey] = controlkeys.NewIndex(standard)
 		}
-		standard, _ := workspace.GetStandard(standardKey)
 
-		standardControl := standard.GetControl(satisfy.GetControlKey())
-		if standardControl == nil {
-			problems = append(problems, fmt.Sprintf("Could not find reference %s in the standard %s", satisfy.GetControlKey(), standardKey))
+		control, found := indexes[standardKey].Get(satisfy.GetControlKey())
+		if !found {
+			standard, _ := workspace.GetStandard(standardKey)
+			problem := fmt.Sprintf("Could not find reference %s in the standard %s.", satisfy.GetControlKey(), standardKey)
+			problems = append(problems, suggest.Append(problem, satisfy.GetControlKey(), standard.GetSortedControls()))
 		}
 
-		_, found := uniq[standardKey][satisfy.GetControlKey()]
-		if found {
+		// Different spellings of the same control are duplicates as well.
+		controlKey := controlkeys.Normalize(standardKey, satisfy.GetControlKey())
+		if _, found := uniq[standardKey][controlKey]; found {
 			problems = append(problems, fmt.Sprintf("Component %s: Duplicate items found: %s", component.GetKey(), satisfy.GetControlKey()))
 		}
-		uniq[standardKey][satisfy.GetControlKey()] = satisfy
-
-		switch satisfy.GetImplementationStatus() {
-		case "complete", "partial", "not applicable", "planned", "unsatisfied", "unknown", "none":
-			break
-		default:
-			problems = append(problems, fmt.Sprintf("Found non-standard implementation_status: %s.", satisfy.GetImplementationStatus()))
-			break
+		uniq[standardKey][controlKey] = satisfy
+
+		for _, status := range implementationStatuses(satisfy) {
+			if !validStatuses[status] {
+				problems = append(problems, fmt.Sprintf("Found non-standard implementation_status: %s.", status))
+			}
 		}
-		problems = append(problems, validateNarratives(component, satisfy)...)
+		problems = append(problems, validateNarratives(component, satisfy, control)...)
+		problems = append(problems, validateCoveredBy(workspace, component, satisfy)...)
+
+	}
+	return problems
+}
+
+// implementationStatuses returns the statuses to validate. A missing status is reported as well.
+func implementationStatuses(satisfy common.Satisfies) []string {
+	if statuses := satisfy.GetImplementationStatuses(); len(statuses) > 0 {
+		return statuses
+	}
+	// Inherited entries without a status of their own take the statuses of the entry they are inherited from.
+	if satisfy.GetImplementationStatus() == "" && !satisfy.GetInheritedFrom().IsEmpty() {
+		return nil
+	}
+	return []string{satisfy.GetImplementationStatus()}
+}
 
+func validateCoveredBy(workspace common.Workspace, component common.Component, satisfy common.Satisfies) []string {
+	problems := make([]string, 0)
+	for _, coveredBy := range satisfy.GetCoveredBy() {
+		// In case the component key is missing, the verification belongs to the component itself.
+		if coveredBy.ComponentKey == "" {
+			continue
+		}
+		if _, found := workspace.GetComponent(coveredBy.ComponentKey); !found {
+			problem := fmt.Sprintf("Component %s: Satisfy '%s': covered_by references component %s, however that cannot be found in the workspace.", component.GetKey(), satisfy.GetControlKey(), coveredBy.ComponentKey)
+			problems = append(problems, suggest.Append(problem, coveredBy.ComponentKey, getComponentKeys(workspace)))
+		}
 	}
 	return problems
 }
 
-func validateNarratives(component common.Component, satisfy common.Satisfies) []string {
+func getComponentKeys(workspace common.Workspace) []string {
+	var componentKeys []string
+	for _, component := range workspace.GetAllComponents() {
+		componentKeys = append(componentKeys, component.GetKey())
+	}
+	return componentKeys
+}
+
+// validateNarratives checks the narrative keys. When the control has statement parts, the keys are checked against
+// the parts instead of guessing malformed keys by their length.
+func validateNarratives(component common.Component, satisfy common.Satisfies, control common.Control) []string {
 	problems := make([]string, 0)
+	var controlParts []common.ControlPart
+	if control != nil {
+		controlParts = control.GetParts()
+	}
 
 	requireKey := len(satisfy.GetNarratives()) > 1
 	uniqNarratives := make(map[string]bool)
@@ -76,7 +193,7 @@ func validateNarratives(component common.Component, satisfy common.Satisfies) []
 			problems = append(problems, fmt.Sprintf("Component %s: Satisfy '%s': Narrative key is required when multiple narratives are present.", component.GetKey(), satisfy.GetControlKey()))
 		}
 
-		if len(key) > 6 {
+		if len(controlParts) == 0 && len(key) > 6 {
 			problems = append(problems, fmt.Sprintf("Component %s: Satisfy '%s': Long narrative key probably malformed: '%s'", component.GetKey(), satisfy.GetControlKey(), key))
 
 		}
@@ -90,5 +207,14 @@ func validateNarratives(component common.Component, satisfy common.Satisfies) []
 		}
 		uniqNarratives[key] = true
 	}
+	if len(controlParts) > 0 {
+		missing, unknown := parts.Match(controlParts, satisfy.GetNarratives())
+		for _, key := range missing {
+			problems = append(problems, fmt.Sprintf("Component %s: Satisfy '%s': Narrative for part '%s' is missing.", component.GetKey(), satisfy.GetControlKey(), key))
+		}
+		for _, key := range unknown {
+			problems = append(problems, fmt.Sprintf("Component %s: Satisfy '%s': Narrative key '%s' is not a part of the control. Expected one of %s.", component.GetKey(), satisfy.GetControlKey(), key, strings.Join(parts.Keys(controlParts), ", ")))
+		}
+	}
 	return problems
 }
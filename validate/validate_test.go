@@ -0,0 +1,24 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/opencontrol/compliance-masonry/pkg/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNarrativeParts(t *testing.T) {
+	workspace, errs := lib.LoadData("../test/fixtures/opencontrol_fixtures_rev5/", "../test/fixtures/opencontrol_fixtures_rev5/certifications/LATO.yaml")
+	require.Empty(t, errs)
+	component, found := workspace.GetComponent("Database")
+	require.True(t, found)
+	// Check that the narrative keys are checked against the parts of the control, whatever the spelling of its key
+	assert.Equal(t, []string{
+		"Component Database: Satisfy 'AC-02': Narrative for part 'b' is missing.",
+		"Component Database: Satisfy 'AC-02': Narrative key 'e' is not a part of the control. Expected one of a, b, d, d.1, d.2.",
+	}, validateComponent(workspace, component))
+	// Check that narratives addressing all the parts are valid
+	component, _ = workspace.GetComponent("Web")
+	assert.Empty(t, validateComponent(workspace, component))
+}